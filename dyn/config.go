@@ -1,32 +1,56 @@
 package dyn
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/logging"
 	"github.com/nesv/go-dynect/dynect"
 )
 
 type Config struct {
-	CustomerName string
-	Username     string
-	Password     string
+	CustomerName  string
+	Username      string
+	Password      string
+	APIURL        string
+	RateLimit     float64
+	PublishWindow time.Duration
 }
 
 // Client() returns a new client for accessing dyn.
-func (c *Config) Client() (*dynect.ConvenientClient, error) {
+func (c *Config) Client() (*dynClient, error) {
 	client := dynect.NewConvenientClient(c.CustomerName)
+	client.AutoReauthenticate = true
+	client.BaseURL = c.APIURL
+	client.SetRateLimit(c.RateLimit, 1)
+	// reuse record listings between the resources at an FQDN during an apply
+	client.RecordListCacheTTL = 30 * time.Second
 	if logging.IsDebugOrHigher() {
 		client.Verbose(true)
 	}
+	// log what is sent to and received from Dyn with TF_LOG=TRACE
+	client.Debug = logging.LogLevel() == "TRACE"
 
 	err := client.Login(c.Username, c.Password)
 	if err != nil {
-		return nil, fmt.Errorf("Error setting up Dyn client: %s", err)
+		var de *dynect.DynError
+		if errors.As(err, &de) {
+			return nil, fmt.Errorf("Error setting up Dyn client: failed to log in as %s for customer %s, "+
+				"check the credentials: %s", c.Username, c.CustomerName, err)
+		}
+		return nil, fmt.Errorf("Error setting up Dyn client: couldn't reach the Dyn API at %s: %s", c.APIURL, err)
 	}
 
 	log.Printf("[INFO] Dyn client configured for customer: %s, user: %s", c.CustomerName, c.Username)
 
-	return client, nil
+	return &dynClient{ConvenientClient: client, publishWindow: c.PublishWindow}, nil
+}
+
+// isNotFound reports whether err is Dyn answering that the requested object
+// does not exist.
+func isNotFound(err error) bool {
+	var de *dynect.DynError
+	return errors.As(err, &de) && de.StatusCode == 404
 }
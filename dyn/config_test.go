@@ -0,0 +1,575 @@
+package dyn
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// handlerTransport serves requests with a http.Handler instead of sending
+// them over the network.
+type handlerTransport struct {
+	http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+	t.ServeHTTP(w, req)
+	return w.Result(), nil
+}
+
+// fakeDyn is a minimal stand-in for the Dyn API, which expires the session
+// every expireEvery requests if it is set. allRecords is the data returned
+// for every AllRecord listing, if it is set. changes holds the method and
+// path of every request other than a GET, in order, and the first of them
+// which starts with failChange, if it is set, fails. A created record is
+// given the next record ID.
+type fakeDyn struct {
+	expireEvery int
+	allRecords  string
+	failChange  string
+
+	mu        sync.Mutex
+	token     string
+	logins    int
+	requests  int
+	publishes int
+	discards  int
+	logouts   int
+	changes   []string
+	failed    bool
+	lastID    int
+}
+
+func (f *fakeDyn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/REST/Session") {
+		f.logins++
+		f.token = fmt.Sprintf("token-%d", f.logins)
+		fmt.Fprintf(w, `{"status":"success","data":{"token":%q}}`, f.token)
+		return
+	}
+
+	if r.Header.Get("Auth-Token") != f.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"status":"failure","msgs":[{"INFO":"login: Bad or expired credentials"}]}`)
+		return
+	}
+	f.requests++
+	if r.Method != "GET" {
+		change := r.Method + " " + strings.TrimPrefix(r.URL.Path, "/REST/")
+		f.changes = append(f.changes, change)
+		if f.failChange != "" && !f.failed && strings.HasPrefix(change, f.failChange) {
+			f.failed = true
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"failure","msgs":[{"INFO":"rejected"}]}`)
+			return
+		}
+	}
+	if f.expireEvery > 0 && f.requests%f.expireEvery == 0 {
+		f.token = ""
+	}
+	if r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/REST/Zone/") {
+		f.publishes++
+	}
+	if r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/REST/ZoneChanges/") {
+		f.discards++
+	}
+	if r.Method == "DELETE" && strings.HasSuffix(r.URL.Path, "/REST/Session") {
+		f.logouts++
+		f.token = ""
+	}
+
+	switch {
+	case r.Method == "GET" && strings.Contains(r.URL.Path, "/REST/AllRecord/") && f.allRecords != "":
+		fmt.Fprintf(w, `{"status":"success","data":%s}`, f.allRecords)
+	case r.Method == "GET" && strings.Contains(r.URL.Path, "/REST/AllRecord/"):
+		fmt.Fprint(w, `{"status":"success","data":{}}`)
+	case r.Method == "GET":
+		fmt.Fprint(w, `{"status":"success","data":{"zone":"example.com","fqdn":"www.example.com",`+
+			`"record_type":"A","ttl":60,"rdata":{"address":"192.0.2.1"}}}`)
+	case r.Method == "POST" && strings.Contains(r.URL.Path, "Record/"):
+		f.lastID++
+		fmt.Fprintf(w, `{"status":"success","data":{"record_id":%d}}`, f.lastID)
+	default:
+		fmt.Fprint(w, `{"status":"success","data":{}}`)
+	}
+}
+
+func TestDynClient_concurrentUse(t *testing.T) {
+	fake := &fakeDyn{expireEvery: 25}
+	client := dynect.NewConvenientClientWithHTTPClient("customer",
+		&http.Client{Transport: handlerTransport{fake}})
+	client.AutoReauthenticate = true
+	client.Logger = log.New(ioutil.Discard, "", 0)
+	if err := client.Login("user", "password"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				record := &dynect.Record{
+					ID:    fmt.Sprintf("%d", i*10+j),
+					Zone:  "example.com",
+					FQDN:  "www.example.com",
+					Type:  "A",
+					Value: "192.0.2.1",
+				}
+				if err := client.CreateRecord(record); err != nil {
+					errs <- err
+					continue
+				}
+				if err := client.GetRecord(record); err != nil {
+					errs <- err
+					continue
+				}
+				if record.Value != "192.0.2.1" {
+					errs <- fmt.Errorf("got value %q", record.Value)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("err: %s", err)
+	}
+	if fake.logins > 1+fake.requests/fake.expireEvery {
+		t.Errorf("logged in %d times for %d expired sessions", fake.logins, fake.requests/fake.expireEvery)
+	}
+}
+
+func TestDynClient_reauthenticateAgain(t *testing.T) {
+	// the session expires again before the retry after logging in is made,
+	// so the request logs in and retries once more
+	logins := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/REST/Session") {
+			logins++
+			fmt.Fprintf(w, `{"status":"success","data":{"token":"token-%d"}}`, logins)
+			return
+		}
+		if r.Header.Get("Auth-Token") != "token-3" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"status":"failure","msgs":[{"INFO":"login: Bad or expired credentials"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"success","data":{"zone":"example.com","fqdn":"www.example.com",`+
+			`"record_type":"A","ttl":60,"rdata":{"address":"192.0.2.1"}}}`)
+	})
+	client := dynect.NewConvenientClientWithHTTPClient("customer",
+		&http.Client{Transport: handlerTransport{handler}})
+	client.AutoReauthenticate = true
+	client.Logger = log.New(ioutil.Discard, "", 0)
+	if err := client.Login("user", "password"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := client.GetRecord(&dynect.Record{ID: "1", Zone: "example.com", FQDN: "www.example.com", Type: "A"}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if logins != 3 {
+		t.Fatalf("expected 3 logins, got %d", logins)
+	}
+}
+
+func TestDynClient_close(t *testing.T) {
+	fake := &fakeDyn{}
+	client := dynect.NewConvenientClientWithHTTPClient("customer",
+		&http.Client{Transport: handlerTransport{fake}})
+	client.AutoReauthenticate = true
+	client.Logger = log.New(ioutil.Discard, "", 0)
+	if err := client.Login("user", "password"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.LoggedIn() {
+		t.Error("expected the client to be logged out")
+	}
+	// closing again, or using the client, doesn't log back in
+	if err := client.Close(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := client.GetRecord(&dynect.Record{ID: "1", Zone: "example.com", FQDN: "www.example.com", Type: "A"}); err == nil {
+		t.Error("expected an error using a closed client")
+	}
+	if fake.logins != 1 || fake.logouts != 1 {
+		t.Errorf("expected 1 login and 1 logout, got %d and %d", fake.logins, fake.logouts)
+	}
+}
+
+func TestConfig_badCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"status":"failure","msgs":[{"INFO":"login: Credentials you entered did not match"}]}`)
+	}))
+	defer server.Close()
+
+	config := Config{
+		CustomerName: "customer",
+		Username:     "user",
+		Password:     "wrong",
+		APIURL:       server.URL + "/REST",
+	}
+	_, err := config.Client()
+	if err == nil {
+		t.Fatal("expected an error logging in with bad credentials")
+	}
+	if !strings.Contains(err.Error(), "check the credentials") {
+		t.Errorf("expected the error to point at the credentials, got: %s", err)
+	}
+}
+
+func TestConfig_APIURL(t *testing.T) {
+	fake := &fakeDyn{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	config := Config{
+		CustomerName: "customer",
+		Username:     "user",
+		Password:     "password",
+		APIURL:       server.URL + "/REST",
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	client.Logger = log.New(ioutil.Discard, "", 0)
+
+	record := &dynect.Record{ID: "1", Zone: "example.com", FQDN: "www.example.com", Type: "A"}
+	if err := client.GetRecord(record); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if fake.logins != 1 || fake.requests != 1 {
+		t.Errorf("expected 1 login and 1 request to the fake API, got %d and %d", fake.logins, fake.requests)
+	}
+}
+
+func TestDynClient_replaceNodeRecords(t *testing.T) {
+	const (
+		a    = `{"zone":"example.com","fqdn":"www.example.com","record_type":"A","record_id":1,"ttl":60,"rdata":{"address":"192.0.2.1"}}`
+		txt  = `{"zone":"example.com","fqdn":"www.example.com","record_type":"TXT","record_id":2,"ttl":60,"rdata":{"txtdata":"hello"}}`
+		soa  = `{"zone":"example.com","fqdn":"www.example.com","record_type":"SOA","record_id":3,"ttl":60,"rdata":{"rname":"admin.example.com."}}`
+		deep = `{"zone":"example.com","fqdn":"a.www.example.com","record_type":"A","record_id":4,"ttl":60,"rdata":{"address":"192.0.2.9"}}`
+		apex = `{"zone":"example.com","fqdn":"example.com","record_type":"A","record_id":5,"ttl":60,"rdata":{"address":"192.0.2.5"}}`
+		ns   = `{"zone":"example.com","fqdn":"example.com","record_type":"NS","record_id":6,"ttl":86400,"rdata":{"nsdname":"ns1.p01.dynect.net."}}`
+	)
+	cases := []struct {
+		name       string
+		fqdn       string
+		current    string
+		desired    []*dynect.Record
+		failChange string
+		changes    []string
+		errMatch   string
+	}{
+		{
+			name:    "unchanged",
+			current: `{"a_records":[` + a + `]}`,
+			desired: []*dynect.Record{{Type: "A", Value: "192.0.2.1"}},
+			changes: []string{"PUT Zone/example.com"},
+		},
+		{
+			name:    "value changed in place",
+			current: `{"a_records":[` + a + `]}`,
+			desired: []*dynect.Record{{Type: "A", Value: "192.0.2.2"}},
+			changes: []string{"PUT ARecord/example.com/www.example.com/1", "PUT Zone/example.com"},
+		},
+		{
+			name:    "TTL changed",
+			current: `{"a_records":[` + a + `]}`,
+			desired: []*dynect.Record{{Type: "A", Value: "192.0.2.1", TTL: 300}},
+			changes: []string{"PUT ARecord/example.com/www.example.com/1", "PUT Zone/example.com"},
+		},
+		{
+			name:    "record added",
+			current: `{"a_records":[` + a + `]}`,
+			desired: []*dynect.Record{{Type: "A", Value: "192.0.2.1"}, {Type: "TXT", Value: "hello"}},
+			changes: []string{"POST TXTRecord/example.com/www.example.com", "PUT Zone/example.com"},
+		},
+		{
+			name:    "record removed, leaving the SOA and the node below",
+			current: `{"a_records":[` + a + `,` + deep + `],"txt_records":[` + txt + `],"soa_records":[` + soa + `]}`,
+			desired: []*dynect.Record{{Type: "A", Value: "192.0.2.1"}},
+			changes: []string{"DELETE TXTRecord/example.com/www.example.com/2", "PUT Zone/example.com"},
+		},
+		{
+			name:    "replaced by a CNAME",
+			current: `{"a_records":[` + a + `],"txt_records":[` + txt + `]}`,
+			desired: []*dynect.Record{{Type: "CNAME", Value: "web.example.com."}},
+			changes: []string{
+				"DELETE ARecord/example.com/www.example.com/1",
+				"DELETE TXTRecord/example.com/www.example.com/2",
+				"POST CNAMERecord/example.com/www.example.com",
+				"PUT Zone/example.com",
+			},
+		},
+		{
+			name:     "CNAME alongside another record",
+			current:  `{"a_records":[` + a + `]}`,
+			desired:  []*dynect.Record{{Type: "A", Value: "192.0.2.1"}, {Type: "CNAME", Value: "web.example.com."}},
+			errMatch: "a CNAME can't share its name",
+		},
+		{
+			name:    "apex NS records kept when none are given",
+			fqdn:    "example.com",
+			current: `{"a_records":[` + apex + `],"ns_records":[` + ns + `]}`,
+			desired: []*dynect.Record{{Type: "A", Value: "192.0.2.6"}},
+			changes: []string{"PUT ARecord/example.com/example.com/5", "PUT Zone/example.com"},
+		},
+		{
+			name:    "apex NS records replaced by those given",
+			fqdn:    "example.com",
+			current: `{"ns_records":[` + ns + `]}`,
+			desired: []*dynect.Record{{Type: "NS", Value: "ns1.example.net."}},
+			changes: []string{"PUT NSRecord/example.com/example.com/6", "PUT Zone/example.com"},
+		},
+		{
+			name:       "failed create undoes the changes before it",
+			current:    `{"a_records":[` + a + `],"txt_records":[` + txt + `]}`,
+			desired:    []*dynect.Record{{Type: "A", Value: "192.0.2.2"}, {Type: "MX", Value: "10 mx.example.com."}},
+			failChange: "POST MXRecord/",
+			changes: []string{
+				"DELETE TXTRecord/example.com/www.example.com/2",
+				"PUT ARecord/example.com/www.example.com/1",
+				"POST MXRecord/example.com/www.example.com",
+				"PUT ARecord/example.com/www.example.com/1",
+				"POST TXTRecord/example.com/www.example.com",
+			},
+			errMatch: "Failed to create Dyn MX record",
+		},
+		{
+			name:       "failed publish undoes every change",
+			current:    `{"a_records":[` + a + `]}`,
+			desired:    []*dynect.Record{{Type: "TXT", Value: "hello"}},
+			failChange: "PUT Zone/",
+			changes: []string{
+				"DELETE ARecord/example.com/www.example.com/1",
+				"POST TXTRecord/example.com/www.example.com",
+				"PUT Zone/example.com",
+				"DELETE TXTRecord/example.com/www.example.com/1",
+				"POST ARecord/example.com/www.example.com",
+			},
+			errMatch: "Failed to publish",
+		},
+	}
+
+	for _, tc := range cases {
+		fake := &fakeDyn{allRecords: tc.current, failChange: tc.failChange}
+		client := dynect.NewConvenientClientWithHTTPClient("customer",
+			&http.Client{Transport: handlerTransport{fake}})
+		client.Logger = log.New(ioutil.Discard, "", 0)
+		if err := client.Login("user", "password"); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		fqdn := tc.fqdn
+		if fqdn == "" {
+			fqdn = "www.example.com"
+		}
+		err := client.ReplaceNodeRecords("example.com", fqdn, tc.desired)
+		if tc.errMatch != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errMatch) {
+				t.Errorf("%s: expected an error matching %q, got: %v", tc.name, tc.errMatch, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: err: %s", tc.name, err)
+		}
+		if !reflect.DeepEqual(fake.changes, tc.changes) {
+			t.Errorf("%s: expected changes %q, got %q", tc.name, tc.changes, fake.changes)
+		}
+		if fake.discards != 0 {
+			t.Errorf("%s: expected no discard, got %d", tc.name, fake.discards)
+		}
+	}
+}
+
+func TestDynClient_retryAfter(t *testing.T) {
+	cases := []struct {
+		retryAfter string
+		requests   int
+		fail       bool
+	}{
+		{"0", 2, false},
+		{"1", 2, false},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 2, false},
+		// no header, or one which can't be read, falls back to the backoff
+		{"", 2, false},
+		{"soon", 2, false},
+		// longer than ServerErrorMaxWait, so the 429 is returned at once
+		{"3600", 1, true},
+	}
+
+	for _, tc := range cases {
+		requests := 0
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				if tc.retryAfter != "" {
+					w.Header().Set("Retry-After", tc.retryAfter)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+				fmt.Fprint(w, `{"status":"failure","msgs":[{"INFO":"too many requests"}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"status":"success","data":{"zone":"example.com","fqdn":"www.example.com",`+
+				`"record_type":"A","ttl":60,"rdata":{"address":"192.0.2.1"}}}`)
+		})
+		client := dynect.NewConvenientClientWithHTTPClient("customer",
+			&http.Client{Transport: handlerTransport{handler}})
+		client.Logger = log.New(ioutil.Discard, "", 0)
+		client.Token = "token"
+		client.ServerErrorMaxWait = 5 * time.Second
+
+		err := client.GetRecord(&dynect.Record{ID: "1", Zone: "example.com", FQDN: "www.example.com", Type: "A"})
+		if (err != nil) != tc.fail {
+			t.Errorf("Retry-After %q: expected failure %t, got err: %v", tc.retryAfter, tc.fail, err)
+		}
+		if requests != tc.requests {
+			t.Errorf("Retry-After %q: expected %d requests, got %d", tc.retryAfter, tc.requests, requests)
+		}
+	}
+}
+
+func TestDynClient_debugRedactsSecrets(t *testing.T) {
+	fake := &fakeDyn{}
+	var logged bytes.Buffer
+	client := dynect.NewConvenientClientWithHTTPClient("customer",
+		&http.Client{Transport: handlerTransport{fake}})
+	client.Logger = log.New(&logged, "", 0)
+	client.Debug = true
+	if err := client.Login("user", "hunter2"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := client.GetRecord(&dynect.Record{ID: "1", Zone: "example.com", FQDN: "www.example.com", Type: "A"}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	out := logged.String()
+	for _, secret := range []string{"hunter2", fake.token} {
+		if strings.Contains(out, secret) {
+			t.Errorf("expected %q to be redacted from the log:\n%s", secret, out)
+		}
+	}
+	for _, want := range []string{"REDACTED", `"user_name":"user"`, "192.0.2.1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the log:\n%s", want, out)
+		}
+	}
+}
+
+func TestDynClient_createRecordsUndo(t *testing.T) {
+	cases := []struct {
+		failChange string
+		changes    []string
+	}{
+		{
+			failChange: "POST TXTRecord/",
+			changes: []string{
+				"POST ARecord/example.com/a.example.com",
+				"POST TXTRecord/example.com/b.example.com",
+				"DELETE ARecord/example.com/a.example.com/1",
+			},
+		},
+		{
+			failChange: "PUT Zone/",
+			changes: []string{
+				"POST ARecord/example.com/a.example.com",
+				"POST TXTRecord/example.com/b.example.com",
+				"PUT Zone/example.com",
+				"DELETE TXTRecord/example.com/b.example.com/2",
+				"DELETE ARecord/example.com/a.example.com/1",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		fake := &fakeDyn{failChange: tc.failChange}
+		client := dynect.NewConvenientClientWithHTTPClient("customer",
+			&http.Client{Transport: handlerTransport{fake}})
+		client.Logger = log.New(ioutil.Discard, "", 0)
+		if err := client.Login("user", "password"); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		// only the records of the batch are undone, leaving the zone's other
+		// pending changes staged
+		err := client.CreateRecords([]*dynect.Record{
+			{Zone: "example.com", Name: "a", Type: "A", Value: "192.0.2.1"},
+			{Zone: "example.com", Name: "b", Type: "TXT", Value: "hello"},
+		})
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.failChange)
+		}
+		if !reflect.DeepEqual(fake.changes, tc.changes) {
+			t.Errorf("%s: expected changes %q, got %q", tc.failChange, tc.changes, fake.changes)
+		}
+		if fake.discards != 0 {
+			t.Errorf("%s: expected no discard, got %d", tc.failChange, fake.discards)
+		}
+	}
+}
+
+func TestDynClient_renameRecordUndo(t *testing.T) {
+	cases := []struct {
+		failChange string
+		changes    []string
+	}{
+		// nothing was staged, so there is nothing to undo
+		{
+			failChange: "POST ARecord/",
+			changes:    []string{"POST ARecord/example.com/new.example.com"},
+		},
+		{
+			failChange: "DELETE ARecord/example.com/old.example.com/",
+			changes: []string{
+				"POST ARecord/example.com/new.example.com",
+				"DELETE ARecord/example.com/old.example.com/7",
+				"DELETE ARecord/example.com/new.example.com/1",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		fake := &fakeDyn{failChange: tc.failChange}
+		client := dynect.NewConvenientClientWithHTTPClient("customer",
+			&http.Client{Transport: handlerTransport{fake}})
+		client.Logger = log.New(ioutil.Discard, "", 0)
+		if err := client.Login("user", "password"); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		record := &dynect.Record{ID: "7", Zone: "example.com", Name: "old", Type: "A", Value: "192.0.2.1"}
+		if err := client.RenameRecord(record, "new"); err == nil {
+			t.Errorf("%s: expected an error", tc.failChange)
+		}
+		if !reflect.DeepEqual(fake.changes, tc.changes) {
+			t.Errorf("%s: expected changes %q, got %q", tc.failChange, tc.changes, fake.changes)
+		}
+		if fake.discards != 0 {
+			t.Errorf("%s: expected no discard, got %d", tc.failChange, fake.discards)
+		}
+	}
+}
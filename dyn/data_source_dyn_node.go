@@ -0,0 +1,102 @@
+package dyn
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func dataSourceDynNode() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDynNodeRead,
+
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"fqdn": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"records": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ttl": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDynNodeRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	zone := strings.TrimSuffix(d.Get("zone").(string), ".")
+	fqdn := strings.TrimSuffix(d.Get("fqdn").(string), ".")
+	// the apex of the zone if no FQDN is given
+	if fqdn == "" {
+		fqdn = zone
+	}
+
+	records, err := client.GetAnyRecords(zone, fqdn)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Couldn't read Dyn records at %s: %s", fqdn, err)
+	}
+
+	result := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		result = append(result, map[string]interface{}{
+			"id":    record.ID,
+			"type":  record.Type,
+			"value": dataSourceRecordValue(record),
+			"ttl":   record.TTL,
+		})
+	}
+	log.Printf("[DEBUG] Found %d Dyn records at %s", len(result), fqdn)
+
+	d.SetId(fmt.Sprintf("%s/%s", zone, fqdn))
+	d.Set("fqdn", fqdn)
+	if err := d.Set("records", result); err != nil {
+		return fmt.Errorf("Failed to set records: %s", err)
+	}
+
+	return nil
+}
+
+// dataSourceRecordValue returns the value of a record as the data sources
+// report it. A record of a type the client doesn't know, such as A6, has no
+// value, so its RData is given as the JSON Dyn returned instead.
+func dataSourceRecordValue(record dynect.Record) string {
+	if record.Unmanaged {
+		return string(record.RawRData)
+	}
+	return record.Value
+}
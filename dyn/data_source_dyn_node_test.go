@@ -0,0 +1,55 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceDynNode_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDataSourceDynNodeConfig_basic, zone, zone),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dyn_node.foobar", "records.#", "2"),
+					resource.TestCheckResourceAttr("data.dyn_node.foobar", "records.0.type", "A"),
+					resource.TestCheckResourceAttr("data.dyn_node.foobar", "records.0.value", "192.168.0.10"),
+					resource.TestCheckResourceAttr("data.dyn_node.foobar", "records.0.ttl", "3600"),
+					resource.TestCheckResourceAttrSet("data.dyn_node.foobar", "records.0.id"),
+					resource.TestCheckResourceAttr("data.dyn_node.foobar", "records.1.type", "TXT"),
+					resource.TestCheckResourceAttr("data.dyn_node.foobar", "records.1.value", "node-test"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceDynNodeConfig_basic = `
+resource "dyn_record" "a" {
+  zone  = "%s"
+  name  = "node.datasource-test"
+  value = "192.168.0.10"
+  type  = "A"
+  ttl   = 3600
+}
+
+resource "dyn_record" "txt" {
+  zone  = "${dyn_record.a.zone}"
+  name  = "node.datasource-test"
+  value = "node-test"
+  type  = "TXT"
+  ttl   = 3600
+}
+
+data "dyn_node" "foobar" {
+  zone = "%s"
+  fqdn = "${dyn_record.txt.fqdn}"
+}`
@@ -0,0 +1,101 @@
+package dyn
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceDynRecord() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDynRecordRead,
+
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"fqdn": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"record_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"value": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"record_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"values": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"ttl": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDynRecordRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	zone := strings.TrimSuffix(d.Get("zone").(string), ".")
+	fqdn := strings.TrimSuffix(d.Get("fqdn").(string), ".")
+	recordType := d.Get("type").(string)
+
+	records, err := client.GetRecordsByFQDN(zone, fqdn)
+	if err != nil {
+		return fmt.Errorf("Couldn't read Dyn records at %s: %s", fqdn, err)
+	}
+
+	var ids, values []string
+	var ttl int
+	for _, record := range records {
+		// the records below the FQDN, such as the NS records of a
+		// delegated child zone, are listed along with it
+		if record.Type != recordType || !strings.EqualFold(record.FQDN, fqdn) {
+			continue
+		}
+		if len(ids) == 0 {
+			ttl = record.TTL
+		}
+		ids = append(ids, record.ID)
+		values = append(values, record.Value)
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("No Dyn %s records found at %s", recordType, fqdn)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", recordType, zone, fqdn))
+	d.Set("record_id", ids[0])
+	d.Set("value", values[0])
+	d.Set("record_ids", ids)
+	d.Set("values", values)
+	d.Set("ttl", ttl)
+
+	return nil
+}
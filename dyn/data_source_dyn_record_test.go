@@ -0,0 +1,90 @@
+package dyn
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func TestDataSourceDynRecordRead_childDelegation(t *testing.T) {
+	fake := &fakeDyn{allRecords: `{"ns_records":[` +
+		`{"zone":"example.com","fqdn":"example.com","record_type":"NS","record_id":1,"ttl":86400,"rdata":{"nsdname":"ns1.p01.dynect.net."}},` +
+		`{"zone":"example.com","fqdn":"child.example.com","record_type":"NS","record_id":2,"ttl":3600,"rdata":{"nsdname":"ns1.child.example.net."}},` +
+		`{"zone":"example.com","fqdn":"example.com","record_type":"NS","record_id":3,"ttl":86400,"rdata":{"nsdname":"ns2.p01.dynect.net."}}]}`}
+	client := dynect.NewConvenientClientWithHTTPClient("customer",
+		&http.Client{Transport: handlerTransport{fake}})
+	client.Logger = log.New(ioutil.Discard, "", 0)
+	if err := client.Login("user", "password"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceDynRecord().Schema, map[string]interface{}{
+		"zone": "example.com",
+		"fqdn": "example.com",
+		"type": "NS",
+	})
+	if err := dataSourceDynRecordRead(d, &dynClient{ConvenientClient: client}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ids := d.Get("record_ids").([]interface{})
+	if len(ids) != 2 || ids[0] != "1" || ids[1] != "3" {
+		t.Errorf("expected only the apex NS records 1 and 3, got %v", ids)
+	}
+	if ttl := d.Get("ttl").(int); ttl != 86400 {
+		t.Errorf("expected the apex TTL 86400, got %d", ttl)
+	}
+}
+
+func TestAccDataSourceDynRecord_roundRobin(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDataSourceDynRecordConfig_roundRobin, zone, zone),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dyn_record.foobar", "type", "A"),
+					resource.TestCheckResourceAttr("data.dyn_record.foobar", "ttl", "3600"),
+					resource.TestCheckResourceAttr("data.dyn_record.foobar", "values.#", "2"),
+					resource.TestCheckResourceAttr("data.dyn_record.foobar", "record_ids.#", "2"),
+					resource.TestCheckResourceAttrSet("data.dyn_record.foobar", "value"),
+					resource.TestCheckResourceAttrSet("data.dyn_record.foobar", "record_id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceDynRecordConfig_roundRobin = `
+resource "dyn_record" "foobar1" {
+  zone  = "%s"
+  name  = "datasource-test"
+  value = "192.168.0.10"
+  type  = "A"
+  ttl   = 3600
+}
+
+resource "dyn_record" "foobar2" {
+  zone  = "${dyn_record.foobar1.zone}"
+  name  = "datasource-test"
+  value = "192.168.0.11"
+  type  = "A"
+  ttl   = 3600
+}
+
+data "dyn_record" "foobar" {
+  zone = "%s"
+  fqdn = "${dyn_record.foobar2.fqdn}"
+  type = "A"
+}`
@@ -0,0 +1,143 @@
+package dyn
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceDynZone() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDynZoneRead,
+
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"serial": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"serial_style": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"rname": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"refresh": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"retry": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"expire": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"minimum": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"ttl": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"include_records": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"records": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fqdn": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ttl": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDynZoneRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	zone := strings.TrimSuffix(d.Get("zone").(string), ".")
+
+	soa, err := client.GetZoneSOA(zone)
+	if err != nil {
+		return fmt.Errorf("Couldn't read Dyn zone %s: %s", zone, err)
+	}
+
+	d.SetId(zone)
+	d.Set("serial", soa.Serial)
+	d.Set("serial_style", soa.SerialStyle)
+	d.Set("rname", soa.RName)
+	d.Set("refresh", soa.Refresh)
+	d.Set("retry", soa.Retry)
+	d.Set("expire", soa.Expire)
+	d.Set("minimum", soa.Minimum)
+	d.Set("ttl", soa.TTL)
+
+	// every record in the zone, only if asked for as it may be large
+	if d.Get("include_records").(bool) {
+		records, err := client.GetZoneRecords(zone)
+		if err != nil {
+			return fmt.Errorf("Couldn't read the records of Dyn zone %s: %s", zone, err)
+		}
+		result := make([]interface{}, 0, len(records))
+		for _, record := range records {
+			result = append(result, map[string]interface{}{
+				"id":    record.ID,
+				"fqdn":  record.FQDN,
+				"type":  record.Type,
+				"value": dataSourceRecordValue(record),
+				"ttl":   record.TTL,
+			})
+		}
+		if err := d.Set("records", result); err != nil {
+			return fmt.Errorf("Failed to set records: %s", err)
+		}
+	}
+
+	return nil
+}
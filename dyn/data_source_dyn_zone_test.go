@@ -0,0 +1,62 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceDynZone_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDataSourceDynZoneConfig_basic, zone),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dyn_zone.foobar", "id", zone),
+					resource.TestCheckResourceAttrSet("data.dyn_zone.foobar", "serial"),
+					resource.TestCheckResourceAttrSet("data.dyn_zone.foobar", "rname"),
+					resource.TestCheckResourceAttrSet("data.dyn_zone.foobar", "refresh"),
+					resource.TestCheckResourceAttrSet("data.dyn_zone.foobar", "minimum"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceDynZone_records(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDataSourceDynZoneConfig_records, zone),
+				Check: resource.ComposeTestCheckFunc(
+					// every zone has at least its SOA and NS records
+					resource.TestMatchResourceAttr("data.dyn_zone.foobar", "records.#", regexp.MustCompile("^([2-9]|[1-9][0-9]+)$")),
+					resource.TestCheckResourceAttrSet("data.dyn_zone.foobar", "records.0.fqdn"),
+					resource.TestCheckResourceAttrSet("data.dyn_zone.foobar", "records.0.type"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceDynZoneConfig_basic = `
+data "dyn_zone" "foobar" {
+  zone = "%s"
+}`
+
+const testAccDataSourceDynZoneConfig_records = `
+data "dyn_zone" "foobar" {
+  zone            = "%s"
+  include_records = true
+}`
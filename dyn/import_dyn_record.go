@@ -2,6 +2,7 @@ package dyn
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -9,19 +10,30 @@ import (
 )
 
 func resourceDynRecordImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	results := make([]*schema.ResourceData, 1, 1)
-
-	client := meta.(*dynect.ConvenientClient)
+	client := meta.(*dynClient)
 
 	values := strings.Split(d.Id(), "/")
 
 	if len(values) != 3 && len(values) != 4 {
-		return nil, fmt.Errorf("invalid id provided, expected format: {type}/{zone}/{fqdn}[/{id}]")
+		return nil, fmt.Errorf("invalid id provided, expected format: {type}/{zone}/{fqdn}[/{id}], {zone}/{fqdn}/{type}[/{id}] or {zone}/{fqdn}/{id}")
+	}
+
+	// A record type is never a number, so {zone}/{fqdn}/{id} leaves the
+	// type to be looked up from the ID.
+	if _, err := strconv.Atoi(values[2]); len(values) == 3 && err == nil {
+		record, err := client.GetRecordByID(values[0], values[1], values[2])
+		if err != nil {
+			return nil, err
+		}
+		return importDynRecord(d, record), nil
 	}
 
-	recordType := values[0]
-	recordZone := values[1]
-	recordFQDN := values[2]
+	// A record type never contains a dot, so if the first part does it is
+	// the zone.
+	recordType, recordZone, recordFQDN := values[0], values[1], values[2]
+	if strings.Contains(values[0], ".") {
+		recordZone, recordFQDN, recordType = values[0], values[1], values[2]
+	}
 
 	var recordID string
 	if len(values) == 4 {
@@ -37,22 +49,27 @@ func resourceDynRecordImportState(d *schema.ResourceData, meta interface{}) ([]*
 		Value: "",
 		Type:  recordType,
 		FQDN:  recordFQDN,
-		TTL:   "",
+		TTL:   0,
 	}
 
-	// If we already have the record ID, use it for the lookup
+	// Look up the record ID if we don't already have it
 	if record.ID == "" {
 		err := client.GetRecordID(record)
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		err := client.GetRecord(record)
-		if err != nil {
-			return nil, err
-		}
 	}
 
+	err := client.GetRecord(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return importDynRecord(d, record), nil
+}
+
+// importDynRecord sets the state of an imported dyn_record from record.
+func importDynRecord(d *schema.ResourceData, record *dynect.Record) []*schema.ResourceData {
 	d.SetId(record.ID)
 	d.Set("name", record.Name)
 	d.Set("zone", record.Zone)
@@ -60,7 +77,5 @@ func resourceDynRecordImportState(d *schema.ResourceData, meta interface{}) ([]*
 	d.Set("type", record.Type)
 	d.Set("fqdn", record.FQDN)
 	d.Set("ttl", record.TTL)
-	results[0] = d
-
-	return results, nil
+	return []*schema.ResourceData{d}
 }
@@ -45,6 +45,42 @@ func TestAccImportDynRecord_A(t *testing.T) {
 	})
 }
 
+func TestAccImportDynRecord_byID(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	checkFn := func(s []*terraform.InstanceState) error {
+		if len(s) != 1 {
+			return fmt.Errorf("expected 1 state: %#v", s)
+		}
+
+		expectedName := "terraform"
+		expectedValue := "192.168.0.10"
+		expectedType := "A"
+		expectedTTL := "3600"
+		return compareState(s[0], expectedName, expectedValue, expectedType, expectedTTL)
+	}
+
+	resourceName := "dyn_record.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_basic, zone),
+			},
+			resource.TestStep{
+				ResourceName:        resourceName,
+				ImportState:         true,
+				ImportStateIdPrefix: fmt.Sprintf("%s/terraform.%s/", zone, zone),
+				ImportStateCheck:    checkFn,
+				ImportStateVerify:   true,
+			},
+		},
+	})
+}
+
 func TestAccImportDynRecord_MX(t *testing.T) {
 	zone := os.Getenv("DYN_ZONE")
 
@@ -82,6 +118,43 @@ func TestAccImportDynRecord_MX(t *testing.T) {
 	})
 }
 
+func TestAccImportDynRecord_topLevelDomain(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	checkFn := func(s []*terraform.InstanceState) error {
+		if len(s) != 1 {
+			return fmt.Errorf("expected 1 state: %#v", s)
+		}
+
+		expectedName := zone
+		expectedValue := "127.0.0.1"
+		expectedType := "A"
+		expectedTTL := "90"
+
+		return compareState(s[0], expectedName, expectedValue, expectedType, expectedTTL)
+	}
+
+	resourceName := "dyn_record.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_topLevelDomain, zone),
+			},
+			resource.TestStep{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s/A", zone, zone),
+				ImportStateCheck:  checkFn,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func compareState(recordState *terraform.InstanceState, expectedName, expectedValue, expectedType, expectedTTL string) error {
 	expectedZone := os.Getenv("DYN_ZONE")
 
@@ -1,8 +1,11 @@
 package dyn
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/nesv/go-dynect/dynect"
 )
 
 // Provider returns a terraform.ResourceProvider.
@@ -29,10 +32,44 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("DYN_PASSWORD", nil),
 				Description: "The Dyn password.",
 			},
+
+			"api_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("DYN_API_URL", dynect.DynAPIPrefix),
+				Description: "The base URL of the Dyn API.",
+			},
+
+			"rate_limit": &schema.Schema{
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("DYN_RATE_LIMIT", 0.0),
+				Description: "The most requests a second to make to the Dyn API. 0 means no limit.",
+			},
+
+			"publish_window": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("DYN_PUBLISH_WINDOW", 0),
+				Description: "How many seconds a changed zone waits for other changes before it is published, so that they are published together. 0 publishes every change straight away.",
+			},
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"dyn_node":   dataSourceDynNode(),
+			"dyn_record": dataSourceDynRecord(),
+			"dyn_zone":   dataSourceDynZone(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"dyn_record": resourceDynRecord(),
+			"dyn_dsf_monitor":          resourceDynDSFMonitor(),
+			"dyn_dsf_response_pool":    resourceDynDSFResponsePool(),
+			"dyn_dsf_traffic_director": resourceDynDSFTrafficDirector(),
+			"dyn_gslb":                 resourceDynGSLB(),
+			"dyn_http_redirect":        resourceDynHTTPRedirect(),
+			"dyn_record":               resourceDynRecord(),
+			"dyn_record_set":           resourceDynRecordSet(),
+			"dyn_zone":                 resourceDynZone(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -41,9 +78,12 @@ func Provider() terraform.ResourceProvider {
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	config := Config{
-		CustomerName: d.Get("customer_name").(string),
-		Username:     d.Get("username").(string),
-		Password:     d.Get("password").(string),
+		CustomerName:  d.Get("customer_name").(string),
+		Username:      d.Get("username").(string),
+		Password:      d.Get("password").(string),
+		APIURL:        d.Get("api_url").(string),
+		RateLimit:     d.Get("rate_limit").(float64),
+		PublishWindow: time.Duration(d.Get("publish_window").(int)) * time.Second,
 	}
 
 	return config.Client()
@@ -0,0 +1,119 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// dynClient is the provider's meta: the Dyn client, with the publishes of its
+// zones which are waiting for the publish window to close. Each provider
+// configuration has its own, so aliases for different Dyn accounts never
+// share a publish, even of zones with the same name.
+type dynClient struct {
+	*dynect.ConvenientClient
+
+	// publishWindow is how long a zone with a change staged in it waits for
+	// other changes before it is published. It is set by the provider's
+	// publish_window, and zones are published straight away when it is 0.
+	//
+	// The provider is given no call once an apply is done, so publishing
+	// each zone once at the end of it isn't possible; a window during which
+	// the changes Terraform makes in parallel gather is the closest to it.
+	publishWindow time.Duration
+
+	publishBatchesMu sync.Mutex
+	publishBatches   map[string]*publishBatch
+}
+
+// publishBatch is a publish of a zone which is shared by all the changes
+// staged in it during the publish window. abandoned is set, guarded by
+// the client's publishBatchesMu, once a change stops waiting for the publish, such as
+// when its timeout is up, so that the batch is discarded rather than
+// published behind its back.
+type publishBatch struct {
+	done      chan struct{}
+	err       error
+	abandoned bool
+}
+
+// publishZone publishes zone, discarding the pending changes if that fails.
+//
+// It must be called with mutex held, and holds it again on return. If
+// publishWindow is set, mutex is released while waiting for the window to
+// close, so that other resources can stage their changes and share the
+// publish. Every change in a batch fails if its publish does.
+func publishZone(client *dynClient, zone string) error {
+	return publishZoneContext(context.Background(), client, zone)
+}
+
+// publishZoneContext is publishZone, giving up once ctx is done. As Dyn
+// can only discard every pending change to a zone, giving up on a publish
+// shared with other changes discards them all, and they fail as well.
+func publishZoneContext(ctx context.Context, client *dynClient, zone string) error {
+	if client.publishWindow == 0 {
+		err := client.PublishZoneContext(ctx, zone)
+		if err != nil {
+			discardChanges(client, zone)
+		}
+		return err
+	}
+
+	client.publishBatchesMu.Lock()
+	if client.publishBatches == nil {
+		client.publishBatches = make(map[string]*publishBatch)
+	}
+	b, ok := client.publishBatches[zone]
+	if !ok {
+		b = &publishBatch{done: make(chan struct{})}
+		client.publishBatches[zone] = b
+		time.AfterFunc(client.publishWindow, func() {
+			// Changes are staged with mutex held, so holding it while the
+			// batch is closed leaves no change staged but in no batch.
+			mutex.Lock()
+			client.publishBatchesMu.Lock()
+			delete(client.publishBatches, zone)
+			abandoned := b.abandoned
+			client.publishBatchesMu.Unlock()
+
+			if abandoned {
+				log.Printf("[WARN] Discarding the changes to Dyn zone %s, as one of them gave up waiting for the publish", zone)
+				discardChanges(client, zone)
+				b.err = fmt.Errorf("Discarded the changes to Dyn zone %s, as another change to it failed", zone)
+			} else {
+				log.Printf("[INFO] Publishing Dyn zone: %s", zone)
+				b.err = client.PublishZone(zone)
+				if b.err != nil {
+					discardChanges(client, zone)
+				}
+			}
+			mutex.Unlock()
+			close(b.done)
+		})
+	}
+	client.publishBatchesMu.Unlock()
+
+	mutex.Unlock()
+	defer mutex.Lock()
+	select {
+	case <-b.done:
+		return b.err
+	case <-ctx.Done():
+		client.publishBatchesMu.Lock()
+		b.abandoned = true
+		client.publishBatchesMu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// discardChanges drops the changes left pending in the session after a failed
+// publish, so that they are not published along with a later change.
+func discardChanges(client *dynClient, zone string) {
+	if err := client.DiscardChanges(zone); err != nil {
+		log.Printf("[WARN] Failed to discard pending changes to Dyn zone %s: %s", zone, err)
+	}
+}
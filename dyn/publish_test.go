@@ -0,0 +1,107 @@
+package dyn
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// testPublishClient returns a client of fake, logged in, which publishes
+// zones once window is up.
+func testPublishClient(t *testing.T, fake *fakeDyn, window time.Duration) *dynClient {
+	client := dynect.NewConvenientClientWithHTTPClient("customer",
+		&http.Client{Transport: handlerTransport{fake}})
+	client.Logger = log.New(ioutil.Discard, "", 0)
+	if err := client.Login("user", "password"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return &dynClient{ConvenientClient: client, publishWindow: window}
+}
+
+func TestPublishZone_window(t *testing.T) {
+	fake := &fakeDyn{}
+	client := testPublishClient(t, fake, 100*time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err := publishZone(client, "example.com"); err != nil {
+				t.Errorf("err: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if fake.publishes != 1 {
+		t.Fatalf("expected 1 publish, got %d", fake.publishes)
+	}
+}
+
+func TestPublishZone_abandoned(t *testing.T) {
+	fake := &fakeDyn{}
+	client := testPublishClient(t, fake, 100*time.Millisecond)
+
+	// one change gives up waiting, so the other, which shares the publish,
+	// fails with it rather than being published without it
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i, timeout := range []time.Duration{10 * time.Millisecond, time.Minute} {
+		wg.Add(1)
+		go func(i int, timeout time.Duration) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			mutex.Lock()
+			defer mutex.Unlock()
+			errs[i] = publishZoneContext(ctx, client, "example.com")
+		}(i, timeout)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			t.Errorf("expected change %d to fail", i)
+		}
+	}
+	if fake.publishes != 0 || fake.discards != 1 {
+		t.Fatalf("expected 0 publishes and 1 discard, got %d and %d", fake.publishes, fake.discards)
+	}
+}
+
+func TestPublishZone_separateClients(t *testing.T) {
+	// provider aliases for different accounts may have zones of the same
+	// name, and each account publishes its own
+	fakes := []*fakeDyn{{}, {}}
+	var wg sync.WaitGroup
+	for _, fake := range fakes {
+		client := testPublishClient(t, fake, 100*time.Millisecond)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				mutex.Lock()
+				defer mutex.Unlock()
+				if err := publishZone(client, "example.com"); err != nil {
+					t.Errorf("err: %s", err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	for i, fake := range fakes {
+		if fake.publishes != 1 {
+			t.Errorf("expected 1 publish by client %d, got %d", i, fake.publishes)
+		}
+	}
+}
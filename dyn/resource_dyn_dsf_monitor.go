@@ -0,0 +1,243 @@
+package dyn
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func resourceDynDSFMonitor() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynDSFMonitorCreate,
+		Read:   resourceDynDSFMonitorRead,
+		Update: resourceDynDSFMonitorUpdate,
+		Delete: resourceDynDSFMonitorDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"label": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"protocol": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					switch v.(string) {
+					case "HTTP", "HTTPS", "PING", "SMTP", "TCP":
+					default:
+						es = append(es, fmt.Errorf("%q must be one of HTTP, HTTPS, PING, SMTP or TCP, got %q", k, v))
+					}
+					return
+				},
+			},
+
+			"interval": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  60,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					switch v.(int) {
+					case 60, 300, 600, 900:
+					default:
+						es = append(es, fmt.Errorf("%q must be one of 60, 300, 600 or 900, got %d", k, v))
+					}
+					return
+				},
+			},
+
+			"retries": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+
+			"response_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+
+			"active": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"path": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"host": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"header": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"expected": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceDynDSFMonitorCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+
+	mon := expandDynDSFMonitor(d)
+	log.Printf("[DEBUG] Dyn DSF monitor create configuration: %#v", mon)
+
+	// create the monitor
+	err := client.CreateDSFMonitor(mon)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to create Dyn DSF monitor: %s", err)
+	}
+	d.SetId(mon.ID)
+
+	mutex.Unlock()
+	return resourceDynDSFMonitorRead(d, meta)
+}
+
+func resourceDynDSFMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	mon, err := client.GetDSFMonitor(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Dyn DSF monitor %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Couldn't find Dyn DSF monitor: %s", err)
+	}
+
+	ints := map[string]string{
+		"interval":       mon.ProbeInterval,
+		"retries":        mon.Retries,
+		"response_count": mon.ResponseCount,
+		"timeout":        mon.Options.Timeout,
+		"port":           mon.Options.Port,
+	}
+	for k, v := range ints {
+		n, err := parseDSFInt(v)
+		if err != nil {
+			return fmt.Errorf("Dyn DSF monitor has an invalid %s %q", k, v)
+		}
+		d.Set(k, n)
+	}
+
+	d.Set("label", mon.Label)
+	d.Set("protocol", mon.Protocol)
+	d.Set("active", mon.Active == "Y")
+	d.Set("path", mon.Options.Path)
+	d.Set("host", mon.Options.Host)
+	d.Set("header", mon.Options.Header)
+	d.Set("expected", mon.Options.Expected)
+
+	return nil
+}
+
+func resourceDynDSFMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+
+	mon := expandDynDSFMonitor(d)
+	mon.ID = d.Id()
+	log.Printf("[DEBUG] Dyn DSF monitor update configuration: %#v", mon)
+
+	// update the monitor
+	err := client.UpdateDSFMonitor(mon)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to update Dyn DSF monitor: %s", err)
+	}
+
+	mutex.Unlock()
+	return resourceDynDSFMonitorRead(d, meta)
+}
+
+func resourceDynDSFMonitorDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	log.Printf("[INFO] Deleting Dyn DSF monitor: %s", d.Id())
+
+	// delete the monitor
+	err := client.DeleteDSFMonitor(d.Id())
+	if err != nil {
+		return fmt.Errorf("Failed to delete Dyn DSF monitor: %s", err)
+	}
+
+	return nil
+}
+
+func expandDynDSFMonitor(d *schema.ResourceData) *dynect.DSFMonitor {
+	mon := &dynect.DSFMonitor{
+		Label:         d.Get("label").(string),
+		Protocol:      d.Get("protocol").(string),
+		ProbeInterval: formatDSFInt(d.Get("interval").(int)),
+		Retries:       strconv.Itoa(d.Get("retries").(int)),
+		ResponseCount: formatDSFInt(d.Get("response_count").(int)),
+		Active:        "N",
+		Options: dynect.DSFMonitorOptions{
+			Timeout:  formatDSFInt(d.Get("timeout").(int)),
+			Port:     formatDSFInt(d.Get("port").(int)),
+			Path:     d.Get("path").(string),
+			Host:     d.Get("host").(string),
+			Header:   d.Get("header").(string),
+			Expected: d.Get("expected").(string),
+		},
+	}
+	if d.Get("active").(bool) {
+		mon.Active = "Y"
+	}
+	return mon
+}
+
+// parseDSFInt parses a number which the DSF API returns as a string, where an
+// empty string means it is not set.
+func parseDSFInt(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(v)
+}
+
+// formatDSFInt formats a number for the DSF API, leaving it out if it is 0.
+func formatDSFInt(v int) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.Itoa(v)
+}
@@ -0,0 +1,100 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynDSFMonitor_Basic(t *testing.T) {
+	if os.Getenv("DYN_DSF") == "" {
+		t.Skip("DYN_DSF must be set on an account with Traffic Director to test DSF monitors")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynDSFMonitorDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynDSFMonitorConfig_basic, 60, "/"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynDSFMonitorExists("dyn_dsf_monitor.foobar"),
+					resource.TestCheckResourceAttr("dyn_dsf_monitor.foobar", "protocol", "HTTP"),
+					resource.TestCheckResourceAttr("dyn_dsf_monitor.foobar", "interval", "60"),
+					resource.TestCheckResourceAttr("dyn_dsf_monitor.foobar", "path", "/"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynDSFMonitorConfig_basic, 300, "/health"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynDSFMonitorExists("dyn_dsf_monitor.foobar"),
+					resource.TestCheckResourceAttr("dyn_dsf_monitor.foobar", "interval", "300"),
+					resource.TestCheckResourceAttr("dyn_dsf_monitor.foobar", "path", "/health"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "dyn_dsf_monitor.foobar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDynDSFMonitorDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*dynClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_dsf_monitor" {
+			continue
+		}
+
+		_, err := client.GetDSFMonitor(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("DSF monitor still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckDynDSFMonitorExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No DSF monitor ID is set")
+		}
+
+		client := testAccProvider.Meta().(*dynClient)
+
+		mon, err := client.GetDSFMonitor(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if mon.ID != rs.Primary.ID {
+			return fmt.Errorf("DSF monitor not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckDynDSFMonitorConfig_basic = `
+resource "dyn_dsf_monitor" "foobar" {
+  label    = "terraform-test"
+  protocol = "HTTP"
+  interval = %d
+  port     = 80
+  path     = "%s"
+  expected = "OK"
+}`
@@ -0,0 +1,180 @@
+package dyn
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func resourceDynDSFResponsePool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynDSFResponsePoolCreate,
+		Read:   resourceDynDSFResponsePoolRead,
+		Update: resourceDynDSFResponsePoolUpdate,
+		Delete: resourceDynDSFResponsePoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynDSFResponsePoolImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"traffic_director_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"label": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"automation": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "auto",
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					switch v.(string) {
+					case "auto", "auto_down", "manual":
+					default:
+						es = append(es, fmt.Errorf("%q must be one of auto, auto_down or manual, got %q", k, v))
+					}
+					return
+				},
+			},
+
+			"core_set_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+
+			"eligible": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDynDSFResponsePoolCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+
+	serviceID := d.Get("traffic_director_id").(string)
+	pool := expandDynDSFResponsePool(d)
+	log.Printf("[DEBUG] Dyn traffic director response pool create configuration: %#v", pool)
+
+	// create the response pool
+	err := client.CreateDSFResponsePool(serviceID, pool)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to create Dyn traffic director response pool: %s", err)
+	}
+	d.SetId(pool.ID)
+
+	mutex.Unlock()
+	return resourceDynDSFResponsePoolRead(d, meta)
+}
+
+func resourceDynDSFResponsePoolRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	pool, err := client.GetDSFResponsePool(d.Get("traffic_director_id").(string), d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Dyn traffic director response pool %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Couldn't find Dyn traffic director response pool: %s", err)
+	}
+
+	coreSetCount, err := strconv.Atoi(pool.CoreSetCount)
+	if err != nil {
+		return fmt.Errorf("Dyn traffic director response pool has an invalid core set count %q", pool.CoreSetCount)
+	}
+
+	d.Set("label", pool.Label)
+	d.Set("automation", pool.Automation)
+	d.Set("core_set_count", coreSetCount)
+	d.Set("eligible", pool.Eligible == "true")
+	d.Set("status", pool.Status)
+
+	return nil
+}
+
+func resourceDynDSFResponsePoolUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+
+	pool := expandDynDSFResponsePool(d)
+	pool.ID = d.Id()
+	log.Printf("[DEBUG] Dyn traffic director response pool update configuration: %#v", pool)
+
+	// update the response pool
+	err := client.UpdateDSFResponsePool(d.Get("traffic_director_id").(string), pool)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to update Dyn traffic director response pool: %s", err)
+	}
+
+	mutex.Unlock()
+	return resourceDynDSFResponsePoolRead(d, meta)
+}
+
+func resourceDynDSFResponsePoolDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	log.Printf("[INFO] Deleting Dyn traffic director response pool: %s", d.Id())
+
+	// delete the response pool
+	err := client.DeleteDSFResponsePool(d.Get("traffic_director_id").(string), d.Id())
+	if err != nil {
+		return fmt.Errorf("Failed to delete Dyn traffic director response pool: %s", err)
+	}
+
+	return nil
+}
+
+func resourceDynDSFResponsePoolImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	values := strings.Split(d.Id(), "/")
+	if len(values) != 2 {
+		return nil, fmt.Errorf("invalid id provided, expected format: {traffic_director_id}/{id}")
+	}
+
+	d.SetId(values[1])
+	d.Set("traffic_director_id", values[0])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandDynDSFResponsePool(d *schema.ResourceData) *dynect.DSFResponsePool {
+	pool := &dynect.DSFResponsePool{
+		Label:        d.Get("label").(string),
+		Automation:   d.Get("automation").(string),
+		CoreSetCount: strconv.Itoa(d.Get("core_set_count").(int)),
+		Eligible:     "false",
+	}
+	if d.Get("eligible").(bool) {
+		pool.Eligible = "true"
+	}
+	return pool
+}
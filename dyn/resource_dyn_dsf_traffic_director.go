@@ -0,0 +1,169 @@
+package dyn
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func resourceDynDSFTrafficDirector() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynDSFTrafficDirectorCreate,
+		Read:   resourceDynDSFTrafficDirectorRead,
+		Update: resourceDynDSFTrafficDirectorUpdate,
+		Delete: resourceDynDSFTrafficDirectorDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"label": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"ttl": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  30,
+			},
+
+			"node": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"zone": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"fqdn": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"active": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDynDSFTrafficDirectorCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+
+	svc := expandDynDSFTrafficDirector(d)
+	log.Printf("[DEBUG] Dyn traffic director create configuration: %#v", svc)
+
+	// create the service
+	err := client.CreateDSFService(svc)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to create Dyn traffic director: %s", err)
+	}
+	d.SetId(svc.ID)
+
+	mutex.Unlock()
+	return resourceDynDSFTrafficDirectorRead(d, meta)
+}
+
+func resourceDynDSFTrafficDirectorRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	svc, err := client.GetDSFService(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Dyn traffic director %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Couldn't find Dyn traffic director: %s", err)
+	}
+
+	ttl, err := strconv.Atoi(svc.TTL)
+	if err != nil {
+		return fmt.Errorf("Dyn traffic director has an invalid TTL %q", svc.TTL)
+	}
+
+	nodes := make([]interface{}, 0, len(svc.Nodes))
+	for _, node := range svc.Nodes {
+		nodes = append(nodes, map[string]interface{}{
+			"zone": node.Zone,
+			"fqdn": node.FQDN,
+		})
+	}
+
+	d.Set("label", svc.Label)
+	d.Set("ttl", ttl)
+	d.Set("active", svc.Active == "Y")
+	if err := d.Set("node", nodes); err != nil {
+		return fmt.Errorf("Failed to set node: %s", err)
+	}
+
+	return nil
+}
+
+func resourceDynDSFTrafficDirectorUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+
+	svc := expandDynDSFTrafficDirector(d)
+	svc.ID = d.Id()
+	log.Printf("[DEBUG] Dyn traffic director update configuration: %#v", svc)
+
+	// update the service
+	err := client.UpdateDSFService(svc)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to update Dyn traffic director: %s", err)
+	}
+
+	mutex.Unlock()
+	return resourceDynDSFTrafficDirectorRead(d, meta)
+}
+
+func resourceDynDSFTrafficDirectorDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	log.Printf("[INFO] Deleting Dyn traffic director: %s", d.Id())
+
+	// delete the service
+	err := client.DeleteDSFService(d.Id())
+	if err != nil {
+		return fmt.Errorf("Failed to delete Dyn traffic director: %s", err)
+	}
+
+	return nil
+}
+
+func expandDynDSFTrafficDirector(d *schema.ResourceData) *dynect.DSFService {
+	svc := &dynect.DSFService{
+		Label: d.Get("label").(string),
+		TTL:   strconv.Itoa(d.Get("ttl").(int)),
+		Nodes: []dynect.DSFNode{},
+	}
+	for _, v := range d.Get("node").(*schema.Set).List() {
+		node := v.(map[string]interface{})
+		svc.Nodes = append(svc.Nodes, dynect.DSFNode{
+			Zone: node["zone"].(string),
+			FQDN: node["fqdn"].(string),
+		})
+	}
+	return svc
+}
@@ -0,0 +1,107 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynDSFTrafficDirector_Basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+	if os.Getenv("DYN_DSF") == "" {
+		t.Skip("DYN_DSF must be set on an account with Traffic Director to test traffic directors")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynDSFTrafficDirectorDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynDSFTrafficDirectorConfig_basic, "terraform-test", zone, zone, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynDSFTrafficDirectorExists("dyn_dsf_traffic_director.foobar"),
+					resource.TestCheckResourceAttr("dyn_dsf_traffic_director.foobar", "label", "terraform-test"),
+					resource.TestCheckResourceAttr("dyn_dsf_traffic_director.foobar", "node.#", "1"),
+					resource.TestCheckResourceAttr("dyn_dsf_response_pool.foobar", "core_set_count", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynDSFTrafficDirectorConfig_basic, "terraform-test-updated", zone, zone, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynDSFTrafficDirectorExists("dyn_dsf_traffic_director.foobar"),
+					resource.TestCheckResourceAttr("dyn_dsf_traffic_director.foobar", "label", "terraform-test-updated"),
+					resource.TestCheckResourceAttr("dyn_dsf_response_pool.foobar", "core_set_count", "2"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "dyn_dsf_traffic_director.foobar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDynDSFTrafficDirectorDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*dynClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_dsf_traffic_director" {
+			continue
+		}
+
+		_, err := client.GetDSFService(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Traffic director still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckDynDSFTrafficDirectorExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No traffic director ID is set")
+		}
+
+		client := testAccProvider.Meta().(*dynClient)
+
+		svc, err := client.GetDSFService(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if svc.ID != rs.Primary.ID {
+			return fmt.Errorf("Traffic director not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckDynDSFTrafficDirectorConfig_basic = `
+resource "dyn_dsf_traffic_director" "foobar" {
+  label = "%s"
+
+  node {
+    zone = "%s"
+    fqdn = "dsf-test.%s"
+  }
+}
+
+resource "dyn_dsf_response_pool" "foobar" {
+  traffic_director_id = "${dyn_dsf_traffic_director.foobar.id}"
+  label               = "terraform-test"
+  core_set_count      = %d
+}`
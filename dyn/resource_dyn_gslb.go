@@ -0,0 +1,342 @@
+package dyn
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func resourceDynGSLB() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynGSLBCreate,
+		Read:   resourceDynGSLBRead,
+		Update: resourceDynGSLBUpdate,
+		Delete: resourceDynGSLBDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynGSLBImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"fqdn": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"contact_nickname": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"ttl": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  30,
+			},
+
+			"auto_recover": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"monitor": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"interval": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"retries": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"timeout": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"port": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"path": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"host": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"header": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"expected": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"region": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region_code": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"serve_count": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"failover_mode": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"failover_data": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"pool": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"address": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"label": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"weight": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+										Default:  1,
+									},
+									"serve_mode": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  "obey",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDynGSLBCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+
+	svc := expandDynGSLB(d)
+	log.Printf("[DEBUG] Dyn GSLB create configuration: %#v", svc)
+
+	// create the service
+	err := client.CreateGSLB(svc)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to create Dyn GSLB service: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s", svc.Zone, svc.FQDN))
+
+	mutex.Unlock()
+	return resourceDynGSLBRead(d, meta)
+}
+
+func resourceDynGSLBRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	svc, err := client.GetGSLB(d.Get("zone").(string), d.Get("fqdn").(string))
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Dyn GSLB service %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Couldn't find Dyn GSLB service: %s", err)
+	}
+
+	d.Set("contact_nickname", svc.ContactNickname)
+	d.Set("ttl", svc.TTL)
+	d.Set("auto_recover", svc.AutoRecover == "Y")
+	d.Set("status", svc.Status)
+	if err := d.Set("monitor", flattenDynGSLBMonitor(svc.Monitor)); err != nil {
+		return fmt.Errorf("Failed to set monitor: %s", err)
+	}
+	if err := d.Set("region", flattenDynGSLBRegions(svc.Regions)); err != nil {
+		return fmt.Errorf("Failed to set region: %s", err)
+	}
+
+	return nil
+}
+
+func resourceDynGSLBUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+
+	svc := expandDynGSLB(d)
+	log.Printf("[DEBUG] Dyn GSLB update configuration: %#v", svc)
+
+	// update the service
+	err := client.UpdateGSLB(svc)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to update Dyn GSLB service: %s", err)
+	}
+
+	mutex.Unlock()
+	return resourceDynGSLBRead(d, meta)
+}
+
+func resourceDynGSLBDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	log.Printf("[INFO] Deleting Dyn GSLB service: %s", d.Id())
+
+	// delete the service
+	err := client.DeleteGSLB(d.Get("zone").(string), d.Get("fqdn").(string))
+	if err != nil {
+		return fmt.Errorf("Failed to delete Dyn GSLB service: %s", err)
+	}
+
+	return nil
+}
+
+func resourceDynGSLBImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	values := strings.Split(d.Id(), "/")
+	if len(values) != 2 {
+		return nil, fmt.Errorf("invalid id provided, expected format: {zone}/{fqdn}")
+	}
+
+	d.Set("zone", values[0])
+	d.Set("fqdn", values[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandDynGSLB(d *schema.ResourceData) *dynect.GSLBService {
+	svc := &dynect.GSLBService{
+		Zone:            d.Get("zone").(string),
+		FQDN:            d.Get("fqdn").(string),
+		TTL:             d.Get("ttl").(int),
+		ContactNickname: d.Get("contact_nickname").(string),
+		AutoRecover:     "N",
+	}
+	if d.Get("auto_recover").(bool) {
+		svc.AutoRecover = "Y"
+	}
+
+	if v := d.Get("monitor").([]interface{}); len(v) > 0 {
+		m := v[0].(map[string]interface{})
+		svc.Monitor = dynect.GSLBMonitor{
+			Protocol: m["protocol"].(string),
+			Interval: m["interval"].(int),
+			Retries:  m["retries"].(int),
+			Timeout:  m["timeout"].(int),
+			Port:     m["port"].(int),
+			Path:     m["path"].(string),
+			Host:     m["host"].(string),
+			Header:   m["header"].(string),
+			Expected: m["expected"].(string),
+		}
+	}
+
+	for _, v := range d.Get("region").([]interface{}) {
+		r := v.(map[string]interface{})
+		region := dynect.GSLBRegion{
+			RegionCode:   r["region_code"].(string),
+			ServeCount:   r["serve_count"].(int),
+			FailoverMode: r["failover_mode"].(string),
+			FailoverData: r["failover_data"].(string),
+		}
+		for _, pv := range r["pool"].([]interface{}) {
+			p := pv.(map[string]interface{})
+			region.Pool = append(region.Pool, dynect.GSLBPoolEntry{
+				Address:   p["address"].(string),
+				Label:     p["label"].(string),
+				Weight:    p["weight"].(int),
+				ServeMode: p["serve_mode"].(string),
+			})
+		}
+		svc.Regions = append(svc.Regions, region)
+	}
+
+	return svc
+}
+
+func flattenDynGSLBMonitor(m dynect.GSLBMonitor) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"protocol": m.Protocol,
+			"interval": m.Interval,
+			"retries":  m.Retries,
+			"timeout":  m.Timeout,
+			"port":     m.Port,
+			"path":     m.Path,
+			"host":     m.Host,
+			"header":   m.Header,
+			"expected": m.Expected,
+		},
+	}
+}
+
+func flattenDynGSLBRegions(regions []dynect.GSLBRegion) []interface{} {
+	result := make([]interface{}, 0, len(regions))
+	for _, region := range regions {
+		pool := make([]interface{}, 0, len(region.Pool))
+		for _, p := range region.Pool {
+			pool = append(pool, map[string]interface{}{
+				"address":    p.Address,
+				"label":      p.Label,
+				"weight":     p.Weight,
+				"serve_mode": p.ServeMode,
+			})
+		}
+		result = append(result, map[string]interface{}{
+			"region_code":   region.RegionCode,
+			"serve_count":   region.ServeCount,
+			"failover_mode": region.FailoverMode,
+			"failover_data": region.FailoverData,
+			"pool":          pool,
+		})
+	}
+	return result
+}
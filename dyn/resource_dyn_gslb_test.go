@@ -0,0 +1,113 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynGSLB_Basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+	contact := os.Getenv("DYN_GSLB_CONTACT")
+	if contact == "" {
+		t.Skip("DYN_GSLB_CONTACT must be set to a contact nickname on an account with GSLB to test GSLB services")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynGSLBDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynGSLBConfig_basic, zone, zone, contact, "192.168.0.10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynGSLBExists("dyn_gslb.foobar"),
+					resource.TestCheckResourceAttr("dyn_gslb.foobar", "fqdn", "gslb-test."+zone),
+					resource.TestCheckResourceAttr("dyn_gslb.foobar", "monitor.0.protocol", "HTTP"),
+					resource.TestCheckResourceAttr("dyn_gslb.foobar", "region.0.region_code", "global"),
+					resource.TestCheckResourceAttr("dyn_gslb.foobar", "region.0.pool.0.address", "192.168.0.10"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynGSLBConfig_basic, zone, zone, contact, "192.168.0.11"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynGSLBExists("dyn_gslb.foobar"),
+					resource.TestCheckResourceAttr("dyn_gslb.foobar", "region.0.pool.0.address", "192.168.0.11"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "dyn_gslb.foobar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDynGSLBDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*dynClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_gslb" {
+			continue
+		}
+
+		_, err := client.GetGSLB(rs.Primary.Attributes["zone"], rs.Primary.Attributes["fqdn"])
+		if err == nil {
+			return fmt.Errorf("GSLB service still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckDynGSLBExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No GSLB service ID is set")
+		}
+
+		client := testAccProvider.Meta().(*dynClient)
+
+		svc, err := client.GetGSLB(rs.Primary.Attributes["zone"], rs.Primary.Attributes["fqdn"])
+		if err != nil {
+			return err
+		}
+
+		if svc.FQDN != rs.Primary.Attributes["fqdn"] {
+			return fmt.Errorf("GSLB service not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckDynGSLBConfig_basic = `
+resource "dyn_gslb" "foobar" {
+  zone             = "%s"
+  fqdn             = "gslb-test.%s"
+  contact_nickname = "%s"
+
+  monitor {
+    protocol = "HTTP"
+    interval = 5
+    path     = "/"
+  }
+
+  region {
+    region_code = "global"
+
+    pool {
+      address = "%s"
+    }
+  }
+}`
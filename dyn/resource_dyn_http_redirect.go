@@ -0,0 +1,192 @@
+package dyn
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func resourceDynHTTPRedirect() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynHTTPRedirectCreate,
+		Read:   resourceDynHTTPRedirectRead,
+		Update: resourceDynHTTPRedirectUpdate,
+		Delete: resourceDynHTTPRedirectDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynHTTPRedirectImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"fqdn": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"code": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  301,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					if code := v.(int); code != 301 && code != 302 {
+						es = append(es, fmt.Errorf("%q must be 301 or 302, got %d", k, code))
+					}
+					return
+				},
+			},
+
+			"keep_uri": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceDynHTTPRedirectCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+
+	redirect := expandDynHTTPRedirect(d)
+	log.Printf("[DEBUG] Dyn HTTP redirect create configuration: %#v", redirect)
+
+	// create the service
+	err := client.CreateHTTPRedirect(redirect)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to create Dyn HTTP redirect: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s", redirect.Zone, redirect.FQDN))
+
+	// publish the zone
+	err = publishZone(client, redirect.Zone)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
+	}
+
+	mutex.Unlock()
+	return resourceDynHTTPRedirectRead(d, meta)
+}
+
+func resourceDynHTTPRedirectRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	redirect, err := client.GetHTTPRedirect(d.Get("zone").(string), d.Get("fqdn").(string))
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Dyn HTTP redirect %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Couldn't find Dyn HTTP redirect: %s", err)
+	}
+
+	code, err := strconv.Atoi(redirect.Code)
+	if err != nil {
+		return fmt.Errorf("Dyn HTTP redirect has an invalid code %q", redirect.Code)
+	}
+
+	d.Set("url", redirect.URL)
+	d.Set("code", code)
+	d.Set("keep_uri", redirect.KeepURI == "Y")
+
+	return nil
+}
+
+func resourceDynHTTPRedirectUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+
+	redirect := expandDynHTTPRedirect(d)
+	log.Printf("[DEBUG] Dyn HTTP redirect update configuration: %#v", redirect)
+
+	// update the service
+	err := client.UpdateHTTPRedirect(redirect)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to update Dyn HTTP redirect: %s", err)
+	}
+
+	// publish the zone
+	err = publishZone(client, redirect.Zone)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
+	}
+
+	mutex.Unlock()
+	return resourceDynHTTPRedirectRead(d, meta)
+}
+
+func resourceDynHTTPRedirectDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	zone := d.Get("zone").(string)
+
+	log.Printf("[INFO] Deleting Dyn HTTP redirect: %s", d.Id())
+
+	// delete the service
+	err := client.DeleteHTTPRedirect(zone, d.Get("fqdn").(string))
+	if err != nil {
+		return fmt.Errorf("Failed to delete Dyn HTTP redirect: %s", err)
+	}
+
+	// publish the zone
+	err = publishZone(client, zone)
+	if err != nil {
+		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
+	}
+
+	return nil
+}
+
+func resourceDynHTTPRedirectImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	values := strings.Split(d.Id(), "/")
+	if len(values) != 2 {
+		return nil, fmt.Errorf("invalid id provided, expected format: {zone}/{fqdn}")
+	}
+
+	d.Set("zone", values[0])
+	d.Set("fqdn", values[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandDynHTTPRedirect(d *schema.ResourceData) *dynect.HTTPRedirect {
+	redirect := &dynect.HTTPRedirect{
+		Zone:    d.Get("zone").(string),
+		FQDN:    d.Get("fqdn").(string),
+		URL:     d.Get("url").(string),
+		Code:    strconv.Itoa(d.Get("code").(int)),
+		KeepURI: "N",
+	}
+	if d.Get("keep_uri").(bool) {
+		redirect.KeepURI = "Y"
+	}
+	return redirect
+}
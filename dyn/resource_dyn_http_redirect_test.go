@@ -0,0 +1,98 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynHTTPRedirect_Basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynHTTPRedirectDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynHTTPRedirectConfig_basic, zone, zone, "https://www.terraform.io/", 301, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynHTTPRedirectExists("dyn_http_redirect.foobar"),
+					resource.TestCheckResourceAttr("dyn_http_redirect.foobar", "url", "https://www.terraform.io/"),
+					resource.TestCheckResourceAttr("dyn_http_redirect.foobar", "code", "301"),
+					resource.TestCheckResourceAttr("dyn_http_redirect.foobar", "keep_uri", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynHTTPRedirectConfig_basic, zone, zone, "https://www.terraform.io/docs/", 302, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynHTTPRedirectExists("dyn_http_redirect.foobar"),
+					resource.TestCheckResourceAttr("dyn_http_redirect.foobar", "url", "https://www.terraform.io/docs/"),
+					resource.TestCheckResourceAttr("dyn_http_redirect.foobar", "code", "302"),
+					resource.TestCheckResourceAttr("dyn_http_redirect.foobar", "keep_uri", "true"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "dyn_http_redirect.foobar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDynHTTPRedirectDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*dynClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_http_redirect" {
+			continue
+		}
+
+		_, err := client.GetHTTPRedirect(rs.Primary.Attributes["zone"], rs.Primary.Attributes["fqdn"])
+		if err == nil {
+			return fmt.Errorf("HTTP redirect still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckDynHTTPRedirectExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No HTTP redirect ID is set")
+		}
+
+		client := testAccProvider.Meta().(*dynClient)
+
+		redirect, err := client.GetHTTPRedirect(rs.Primary.Attributes["zone"], rs.Primary.Attributes["fqdn"])
+		if err != nil {
+			return err
+		}
+
+		if redirect.URL != rs.Primary.Attributes["url"] {
+			return fmt.Errorf("HTTP redirect not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckDynHTTPRedirectConfig_basic = `
+resource "dyn_http_redirect" "foobar" {
+  zone     = "%s"
+  fqdn     = "redirect-test.%s"
+  url      = "%s"
+  code     = %d
+  keep_uri = %t
+}`
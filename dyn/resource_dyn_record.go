@@ -1,10 +1,16 @@
 package dyn
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/nesv/go-dynect/dynect"
@@ -22,6 +28,12 @@ func resourceDynRecord() *schema.Resource {
 			State: resourceDynRecordImportState,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"zone": &schema.Schema{
 				Type:     schema.TypeString,
@@ -32,7 +44,6 @@ func resourceDynRecord() *schema.Resource {
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				DiffSuppressFunc: func(k, oldV, newV string, d *schema.ResourceData) bool {
 					// Records for top level domain
 					zone := d.Get("zone").(string)
@@ -40,6 +51,16 @@ func resourceDynRecord() *schema.Resource {
 						return true
 					}
 
+					// Names given fully qualified, which are read back
+					// relative to the zone
+					newV = strings.TrimSuffix(newV, ".")
+					if strings.EqualFold(newV, zone) {
+						return oldV == zone
+					}
+					if strings.HasSuffix(strings.ToLower(newV), "."+strings.ToLower(zone)) {
+						newV = newV[:len(newV)-len(zone)-1]
+					}
+
 					return oldV == newV
 				},
 			},
@@ -59,8 +80,8 @@ func resourceDynRecord() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 				DiffSuppressFunc: func(k, oldV, newV string, d *schema.ResourceData) bool {
-					recordType := d.Get("type").(string)
-					if recordType == "CNAME" || recordType == "NS" || recordType == "MX" {
+					switch d.Get("type").(string) {
+					case "ALIAS", "CNAME", "DNAME", "KX", "MX", "NS", "PTR", "SRV":
 						// We expect FQDN here, which may or may not have a trailing dot
 						if !strings.HasSuffix(oldV, ".") {
 							oldV += "."
@@ -68,6 +89,39 @@ func resourceDynRecord() *schema.Resource {
 						if !strings.HasSuffix(newV, ".") {
 							newV += "."
 						}
+					case "RP":
+						return normalizeRPValue(oldV) == normalizeRPValue(newV)
+					case "PX":
+						return normalizePXValue(oldV) == normalizePXValue(newV)
+					case "NSAP":
+						// The 0x prefix and any dots are optional
+						oldNSAP, _ := dynect.NormalizeNSAP(oldV)
+						newNSAP, _ := dynect.NormalizeNSAP(newV)
+						return oldNSAP == newNSAP
+					case "SOA":
+						return normalizeRName(oldV) == normalizeRName(newV)
+					case "CAA":
+						return normalizeCAAValue(oldV) == normalizeCAAValue(newV)
+					case "CERT":
+						return normalizeCERTValue(oldV) == normalizeCERTValue(newV)
+					case "CSYNC":
+						return normalizeCSYNCValue(oldV) == normalizeCSYNCValue(newV)
+					case "IPSECKEY":
+						return normalizeIPSECKEYValue(oldV) == normalizeIPSECKEYValue(newV)
+					case "LOC":
+						return normalizeLOCValue(oldV) == normalizeLOCValue(newV)
+					case "TLSA":
+						return normalizeTLSAValue(oldV) == normalizeTLSAValue(newV)
+					case "AAAA":
+						// The same address may be spelled several ways, but a plain
+						// IPv4 address isn't the IPv4-mapped one net.IP equates it to
+						oldIP, newIP := net.ParseIP(oldV), net.ParseIP(newV)
+						if oldIP != nil && newIP != nil && strings.Contains(oldV, ":") == strings.Contains(newV, ":") {
+							return oldIP.Equal(newIP)
+						}
+					case "CDS", "DS", "SSHFP":
+						// The hex digest or fingerprint is stored in lower case
+						return strings.EqualFold(oldV, newV)
 					}
 
 					return oldV == newV
@@ -75,6 +129,42 @@ func resourceDynRecord() *schema.Resource {
 			},
 
 			"ttl": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSeconds,
+			},
+
+			// The timers and serial style of an SOA record
+			"refresh": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSeconds,
+			},
+
+			"retry": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSeconds,
+			},
+
+			"expire": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSeconds,
+			},
+
+			"minimum": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSeconds,
+			},
+
+			"serial_style": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
@@ -86,36 +176,51 @@ func resourceDynRecord() *schema.Resource {
 func resourceDynRecordCreate(d *schema.ResourceData, meta interface{}) error {
 	mutex.Lock()
 
-	client := meta.(*dynect.ConvenientClient)
+	client := meta.(*dynClient)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	record := &dynect.Record{
 		Name:  d.Get("name").(string),
 		Zone:  d.Get("zone").(string),
 		Type:  d.Get("type").(string),
-		TTL:   d.Get("ttl").(string),
+		TTL:   d.Get("ttl").(int),
 		Value: d.Get("value").(string),
 	}
+	setSOAFields(d, record)
 	log.Printf("[DEBUG] Dyn record create configuration: %#v", record)
 
-	// create the record
-	err := client.CreateRecord(record)
+	var err error
+	if record.Type == "SOA" {
+		// every zone has exactly one SOA record, which can only be updated
+		err = client.GetRecordIDContext(ctx, record)
+		if err == nil {
+			err = client.UpdateRecordContext(ctx, record)
+		}
+		forgetZoneDefaultTTL(client, record.Zone)
+	} else {
+		// create the record
+		err = client.CreateRecordContext(ctx, record)
+	}
 	if err != nil {
 		mutex.Unlock()
 		return fmt.Errorf("Failed to create Dyn record: %s", err)
 	}
 
 	// publish the zone
-	err = client.PublishZone(record.Zone)
+	err = publishZoneContext(ctx, client, record.Zone)
 	if err != nil {
 		mutex.Unlock()
 		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
 	}
 
-	// get the record ID
-	err = client.GetRecordID(record)
-	if err != nil {
-		mutex.Unlock()
-		return fmt.Errorf("%s", err)
+	// get the record ID, unless Dyn returned it on create
+	if record.ID == "" {
+		err = client.GetRecordIDContext(ctx, record)
+		if err != nil {
+			mutex.Unlock()
+			return fmt.Errorf("%s", err)
+		}
 	}
 	d.SetId(record.ID)
 
@@ -127,28 +232,51 @@ func resourceDynRecordRead(d *schema.ResourceData, meta interface{}) error {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	client := meta.(*dynect.ConvenientClient)
+	client := meta.(*dynClient)
 
 	record := &dynect.Record{
-		ID:   d.Id(),
-		Name: d.Get("name").(string),
-		Zone: d.Get("zone").(string),
-		TTL:  d.Get("ttl").(string),
-		FQDN: d.Get("fqdn").(string),
-		Type: d.Get("type").(string),
+		ID:    d.Id(),
+		Name:  d.Get("name").(string),
+		Zone:  d.Get("zone").(string),
+		TTL:   d.Get("ttl").(int),
+		FQDN:  d.Get("fqdn").(string),
+		Type:  d.Get("type").(string),
+		Value: d.Get("value").(string),
 	}
 
 	err := client.GetRecord(record)
 	if err != nil {
+		if errors.Is(err, dynect.ErrRecordNotFound) {
+			log.Printf("[WARN] Dyn record %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("Couldn't find Dyn record: %s", err)
 	}
 
+	// A ttl of 0 means the zone default, which Dyn reads back resolved, so
+	// keep it as 0 for as long as the record still has the default.
+	if d.Get("ttl").(int) == 0 && record.Type != "SOA" {
+		defaultTTL, err := zoneDefaultTTL(client, record.Zone)
+		if err != nil {
+			return err
+		}
+		if record.TTL == defaultTTL {
+			record.TTL = 0
+		}
+	}
+
 	d.Set("zone", record.Zone)
 	d.Set("fqdn", record.FQDN)
 	d.Set("name", record.Name)
 	d.Set("type", record.Type)
 	d.Set("ttl", record.TTL)
 	d.Set("value", record.Value)
+	d.Set("refresh", record.Refresh)
+	d.Set("retry", record.Retry)
+	d.Set("expire", record.Expire)
+	d.Set("minimum", record.Minimum)
+	d.Set("serial_style", record.SerialStyle)
 
 	return nil
 }
@@ -156,34 +284,62 @@ func resourceDynRecordRead(d *schema.ResourceData, meta interface{}) error {
 func resourceDynRecordUpdate(d *schema.ResourceData, meta interface{}) error {
 	mutex.Lock()
 
-	client := meta.(*dynect.ConvenientClient)
+	client := meta.(*dynClient)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 
 	record := &dynect.Record{
 		ID:    d.Id(),
 		Name:  d.Get("name").(string),
 		Zone:  d.Get("zone").(string),
-		TTL:   d.Get("ttl").(string),
+		TTL:   d.Get("ttl").(int),
 		Type:  d.Get("type").(string),
 		Value: d.Get("value").(string),
 	}
+	setSOAFields(d, record)
+
+	// A TTL of 0 is left out of the request, which would keep the old TTL,
+	// so going back to the zone default sends the default itself.
+	if d.HasChange("ttl") && record.TTL == 0 && record.Type != "SOA" {
+		defaultTTL, err := zoneDefaultTTL(client, record.Zone)
+		if err != nil {
+			mutex.Unlock()
+			return err
+		}
+		record.TTL = defaultTTL
+	}
 	log.Printf("[DEBUG] Dyn record update configuration: %#v", record)
 
-	// update the record
-	err := client.UpdateRecord(record)
+	// update the record, leaving its RData alone if only the TTL changed,
+	// or move it to its new name, which Dyn can't do in place
+	var err error
+	if d.HasChange("name") {
+		oldName, _ := d.GetChange("name")
+		record.Name = oldName.(string)
+		record.FQDN = d.Get("fqdn").(string)
+		err = client.RenameRecordContext(ctx, record, d.Get("name").(string))
+	} else if d.HasChange("ttl") && !d.HasChange("value") && record.Type != "SOA" {
+		err = client.UpdateRecordTTLContext(ctx, record, record.TTL)
+	} else {
+		err = client.UpdateRecordContext(ctx, record)
+	}
+	if record.Type == "SOA" {
+		forgetZoneDefaultTTL(client, record.Zone)
+	}
 	if err != nil {
 		mutex.Unlock()
 		return fmt.Errorf("Failed to update Dyn record: %s", err)
 	}
 
 	// publish the zone
-	err = client.PublishZone(record.Zone)
+	err = publishZoneContext(ctx, client, record.Zone)
 	if err != nil {
 		mutex.Unlock()
 		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
 	}
 
 	// get the record ID
-	err = client.GetRecordID(record)
+	err = client.GetRecordIDContext(ctx, record)
 	if err != nil {
 		mutex.Unlock()
 		return fmt.Errorf("%s", err)
@@ -198,7 +354,9 @@ func resourceDynRecordDelete(d *schema.ResourceData, meta interface{}) error {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	client := meta.(*dynect.ConvenientClient)
+	client := meta.(*dynClient)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
 	record := &dynect.Record{
 		ID:   d.Id(),
@@ -208,19 +366,212 @@ func resourceDynRecordDelete(d *schema.ResourceData, meta interface{}) error {
 		Type: d.Get("type").(string),
 	}
 
+	if record.Type == "SOA" {
+		log.Printf("[WARN] Dyn SOA record %s can't be deleted, removing from state only", record.FQDN)
+		return nil
+	}
+
 	log.Printf("[INFO] Deleting Dyn record: %s, %s", record.FQDN, record.ID)
 
 	// delete the record
-	err := client.DeleteRecord(record)
+	err := client.DeleteRecordContext(ctx, record)
 	if err != nil {
 		return fmt.Errorf("Failed to delete Dyn record: %s", err)
 	}
 
 	// publish the zone
-	err = client.PublishZone(record.Zone)
+	err = publishZoneContext(ctx, client, record.Zone)
 	if err != nil {
 		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
 	}
 
 	return nil
 }
+
+// validateSeconds checks that a TTL or SOA timer is not negative.
+func validateSeconds(v interface{}, k string) (ws []string, es []error) {
+	if v.(int) < 0 {
+		es = append(es, fmt.Errorf("%q must be a number of seconds, got %d", k, v))
+	}
+	return
+}
+
+// setSOAFields copies the SOA timers and serial style from d to record. They
+// are ignored for records of other types.
+func setSOAFields(d *schema.ResourceData, record *dynect.Record) {
+	record.Refresh = d.Get("refresh").(int)
+	record.Retry = d.Get("retry").(int)
+	record.Expire = d.Get("expire").(int)
+	record.Minimum = d.Get("minimum").(int)
+	record.SerialStyle = d.Get("serial_style").(string)
+}
+
+// zoneTTLKey identifies a zone in zoneDefaultTTLs. The client is part of it,
+// as provider aliases may manage zones of the same name in different
+// accounts.
+type zoneTTLKey struct {
+	client *dynClient
+	zone   string
+}
+
+// zoneDefaultTTLs holds the default TTL of each zone read so far in the run,
+// so that refreshing the records which use it reads the zone's SOA record
+// only once. It is guarded by mutex.
+var zoneDefaultTTLs = make(map[zoneTTLKey]int)
+
+// zoneDefaultTTL returns the TTL which records in zone without one of their
+// own get, which is that of the zone's SOA record. It must be called with
+// mutex held.
+func zoneDefaultTTL(client *dynClient, zone string) (int, error) {
+	key := zoneTTLKey{client, zone}
+	if ttl, ok := zoneDefaultTTLs[key]; ok {
+		return ttl, nil
+	}
+	soa, err := client.GetZoneSOA(zone)
+	if err != nil {
+		return 0, fmt.Errorf("Couldn't read the default TTL of Dyn zone %s: %s", zone, err)
+	}
+	zoneDefaultTTLs[key] = soa.TTL
+	return soa.TTL, nil
+}
+
+// forgetZoneDefaultTTL drops the default TTL of zone read so far, once its
+// SOA record is changed. It must be called with mutex held.
+func forgetZoneDefaultTTL(client *dynClient, zone string) {
+	delete(zoneDefaultTTLs, zoneTTLKey{client, zone})
+}
+
+// normalizeCAAValue puts the value of a CAA record, which may be given
+// without quotes, in double quotes as Dyn returns it.
+func normalizeCAAValue(v string) string {
+	fields := strings.Fields(v)
+	if len(fields) < 3 {
+		return v
+	}
+	value := strings.TrimSpace(v)
+	value = strings.TrimSpace(strings.TrimPrefix(value, fields[0]))
+	value = strings.TrimSpace(strings.TrimPrefix(value, fields[1]))
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		value = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+	}
+	return fmt.Sprintf("%s %s %s", fields[0], fields[1], value)
+}
+
+// normalizeCERTValue rewrites a mnemonic CERT type such as PKIX to the
+// numeric form that Dyn returns, and joins a certificate broken over several
+// fields.
+func normalizeCERTValue(v string) string {
+	fields := strings.Fields(v)
+	if len(fields) == 0 {
+		return v
+	}
+	if n, ok := dynect.CertTypes[strings.ToUpper(fields[0])]; ok {
+		fields[0] = strconv.Itoa(n)
+	}
+	if len(fields) > 4 {
+		fields = append(fields[:3], strings.Join(fields[3:], ""))
+	}
+	return strings.Join(fields, " ")
+}
+
+// normalizeCSYNCValue sorts the record types of a CSYNC record and puts them
+// in upper case, as Dyn returns them.
+func normalizeCSYNCValue(v string) string {
+	fields := strings.Fields(v)
+	if len(fields) < 3 {
+		return v
+	}
+	types := make(map[string]bool)
+	for _, t := range fields[2:] {
+		types[strings.ToUpper(t)] = true
+	}
+	sorted := make([]string, 0, len(types))
+	for t := range types {
+		sorted = append(sorted, t)
+	}
+	sort.Strings(sorted)
+	return strings.Join(append(fields[:2], sorted...), " ")
+}
+
+// normalizeIPSECKEYValue adds the trailing dot to the gateway of an IPSECKEY
+// record when it is a domain name, and joins a public key broken over
+// several fields.
+func normalizeIPSECKEYValue(v string) string {
+	fields := strings.Fields(v)
+	if len(fields) < 4 {
+		return v
+	}
+	if fields[1] == "3" && !strings.HasSuffix(fields[3], ".") {
+		fields[3] += "."
+	}
+	return strings.Join(append(fields[:4], strings.Join(fields[4:], "")), " ")
+}
+
+// normalizeTLSAValue joins the association data of a TLSA record when it is
+// broken over several fields.
+func normalizeTLSAValue(v string) string {
+	fields := strings.Fields(v)
+	if len(fields) < 4 {
+		return v
+	}
+	return strings.Join(append(fields[:3], strings.Join(fields[3:], "")), " ")
+}
+
+// normalizeRPValue adds the trailing dot to both domain names of an RP record
+// and fills in the "." TXT pointer when it has been left off.
+func normalizeRPValue(v string) string {
+	fields := strings.Fields(v)
+	if len(fields) == 1 {
+		fields = append(fields, ".")
+	}
+	for i, f := range fields {
+		if !strings.HasSuffix(f, ".") {
+			fields[i] = f + "."
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// normalizePXValue adds the trailing dot to both domain names of a PX record.
+func normalizePXValue(v string) string {
+	fields := strings.Fields(v)
+	for i, f := range fields {
+		if i > 0 && !strings.HasSuffix(f, ".") {
+			fields[i] = f + "."
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// normalizeLOCValue rewrites a LOC record value with its coordinates padded to
+// degrees, minutes and seconds and the RFC 1876 size and precision defaults
+// filled in, so that equivalent spellings of the same location compare equal.
+func normalizeLOCValue(v string) string {
+	var out, numbers []string
+	for _, f := range strings.Fields(strings.ToUpper(v)) {
+		switch f {
+		case "N", "S", "E", "W":
+			for len(numbers) < 3 {
+				numbers = append(numbers, "0")
+			}
+			out = append(out, numbers...)
+			out = append(out, f)
+			numbers = nil
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.TrimSuffix(f, "M"), 64)
+		if err != nil {
+			return v
+		}
+		numbers = append(numbers, strconv.FormatFloat(n, 'f', -1, 64))
+	}
+
+	// What remains is the altitude followed by the optional size,
+	// horizontal precision and vertical precision.
+	for i, def := range []string{"1", "10000", "10"} {
+		if len(numbers) < i+2 {
+			numbers = append(numbers, def)
+		}
+	}
+	return strings.Join(append(out, numbers...), " ")
+}
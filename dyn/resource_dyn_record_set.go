@@ -0,0 +1,253 @@
+package dyn
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func resourceDynRecordSet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynRecordSetCreate,
+		Read:   resourceDynRecordSetRead,
+		Update: resourceDynRecordSetUpdate,
+		Delete: resourceDynRecordSetDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynRecordSetImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"fqdn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					switch v.(string) {
+					case "A", "AAAA", "NS":
+					default:
+						es = append(es, fmt.Errorf("%q must be A, AAAA or NS, got %q", k, v))
+					}
+					return
+				},
+			},
+
+			"values": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      hashDynRecordSetValue,
+			},
+
+			"ttl": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"record_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceDynRecordSetCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+
+	records := expandDynRecordSet(d)
+	log.Printf("[DEBUG] Dyn record set create configuration: %d records", len(records))
+
+	// replace whatever records of the type are at the FQDN
+	err := client.ReplaceRecords(records)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to create Dyn record set: %s", err)
+	}
+	record := records[0]
+	d.Set("fqdn", record.FQDN)
+
+	// publish the zone
+	err = publishZone(client, record.Zone)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s/%s", record.Zone, record.FQDN, record.Type))
+
+	mutex.Unlock()
+	return resourceDynRecordSetRead(d, meta)
+}
+
+func resourceDynRecordSetRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	zone := d.Get("zone").(string)
+	fqdn := d.Get("fqdn").(string)
+	recordType := d.Get("type").(string)
+
+	records, err := client.GetRecordsByFQDN(zone, fqdn)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Couldn't read Dyn record set: %s", err)
+	}
+
+	var ids, values []string
+	var ttl int
+	for _, record := range records {
+		if record.Type != recordType || !strings.EqualFold(record.FQDN, fqdn) {
+			continue
+		}
+		if len(ids) == 0 {
+			ttl = record.TTL
+		}
+		ids = append(ids, record.ID)
+		values = append(values, record.Value)
+	}
+	if len(ids) == 0 {
+		log.Printf("[WARN] Dyn record set %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("ttl", ttl)
+	d.Set("record_ids", ids)
+	if err := d.Set("values", values); err != nil {
+		return fmt.Errorf("Failed to set values: %s", err)
+	}
+
+	return nil
+}
+
+func resourceDynRecordSetUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+
+	records := expandDynRecordSet(d)
+	log.Printf("[DEBUG] Dyn record set update configuration: %d records", len(records))
+
+	// replace the records
+	err := client.ReplaceRecords(records)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to update Dyn record set: %s", err)
+	}
+
+	// publish the zone
+	err = publishZone(client, records[0].Zone)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
+	}
+
+	mutex.Unlock()
+	return resourceDynRecordSetRead(d, meta)
+}
+
+func resourceDynRecordSetDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	zone := d.Get("zone").(string)
+	fqdn := d.Get("fqdn").(string)
+	// a zone can't be left without nameservers, so the apex NS set is only
+	// forgotten
+	if d.Get("type").(string) == "NS" && strings.EqualFold(fqdn, zone) {
+		log.Printf("[WARN] Leaving the NS records of Dyn zone %s in place, removing the record set from state", zone)
+		return nil
+	}
+	log.Printf("[INFO] Deleting Dyn record set: %s", d.Id())
+
+	// delete the records
+	err := client.DeleteRecords(zone, fqdn, d.Get("type").(string))
+	if err != nil {
+		return fmt.Errorf("Failed to delete Dyn record set: %s", err)
+	}
+
+	// publish the zone
+	err = publishZone(client, zone)
+	if err != nil {
+		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
+	}
+
+	return nil
+}
+
+func resourceDynRecordSetImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	values := strings.Split(d.Id(), "/")
+	if len(values) != 3 {
+		return nil, fmt.Errorf("invalid id provided, expected format: {zone}/{fqdn}/{type}")
+	}
+	zone, fqdn := values[0], values[1]
+
+	d.Set("zone", zone)
+	d.Set("fqdn", fqdn)
+	d.Set("type", values[2])
+	if fqdn != zone {
+		d.Set("name", strings.TrimSuffix(fqdn, "."+zone))
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandDynRecordSet returns a record for each value of the record set in d.
+func expandDynRecordSet(d *schema.ResourceData) []*dynect.Record {
+	var records []*dynect.Record
+	for _, v := range d.Get("values").(*schema.Set).List() {
+		records = append(records, &dynect.Record{
+			Zone:  d.Get("zone").(string),
+			Name:  d.Get("name").(string),
+			Type:  d.Get("type").(string),
+			TTL:   d.Get("ttl").(int),
+			Value: v.(string),
+		})
+	}
+	return records
+}
+
+// hashDynRecordSetValue hashes a value of a record set so that the different
+// spellings of the same value, such as a nameserver with or without its
+// trailing dot, or an IPv6 address with or without leading zeros, are the
+// same member of the set.
+func hashDynRecordSetValue(v interface{}) int {
+	value := v.(string)
+	if ip := net.ParseIP(value); ip != nil {
+		// net.IP writes an IPv4-mapped IPv6 address as plain IPv4
+		if ip4 := ip.To4(); ip4 != nil && strings.Contains(value, ":") {
+			return hashcode.String("::ffff:" + ip4.String())
+		}
+		return hashcode.String(ip.String())
+	}
+	return hashcode.String(strings.ToLower(strings.TrimSuffix(value, ".")))
+}
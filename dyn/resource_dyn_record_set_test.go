@@ -0,0 +1,168 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestHashDynRecordSetValue(t *testing.T) {
+	cases := []struct {
+		a, b string
+		same bool
+	}{
+		{"2001:db8::1", "2001:0DB8:0:0:0:0:0:1", true},
+		{"ns1.example.net.", "NS1.example.net", true},
+		{"::ffff:192.0.2.1", "::FFFF:c000:201", true},
+		{"::ffff:192.0.2.1", "192.0.2.1", false},
+		{"2001:db8::1", "2001:db8::2", false},
+	}
+
+	for _, tc := range cases {
+		if same := hashDynRecordSetValue(tc.a) == hashDynRecordSetValue(tc.b); same != tc.same {
+			t.Errorf("%q and %q: expected the same hash %t, got %t", tc.a, tc.b, tc.same, same)
+		}
+	}
+}
+
+func TestAccDynRecordSet_Basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordSetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordSetConfig_basic, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordSetExists("dyn_record_set.foobar", 3),
+					resource.TestCheckResourceAttr("dyn_record_set.foobar", "values.#", "3"),
+					resource.TestCheckResourceAttr("dyn_record_set.foobar", "record_ids.#", "3"),
+					resource.TestCheckResourceAttr("dyn_record_set.foobar", "fqdn", "record-set-test."+zone),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordSetConfig_updated, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordSetExists("dyn_record_set.foobar", 2),
+					resource.TestCheckResourceAttr("dyn_record_set.foobar", "values.#", "2"),
+					resource.TestCheckResourceAttr("dyn_record_set.foobar", "ttl", "300"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecordSet_NS(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordSetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordSetConfig_NS, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordSetExists("dyn_record_set.foobar", 2),
+					resource.TestCheckResourceAttr("dyn_record_set.foobar", "values.#", "2"),
+					resource.TestCheckResourceAttr("dyn_record_set.foobar", "fqdn", "delegated."+zone),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordSetConfig_NSUpdated, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordSetExists("dyn_record_set.foobar", 3),
+					resource.TestCheckResourceAttr("dyn_record_set.foobar", "values.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDynRecordSetDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*dynClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_record_set" {
+			continue
+		}
+
+		records, err := client.GetRecordsByFQDN(rs.Primary.Attributes["zone"], rs.Primary.Attributes["fqdn"])
+		if err != nil {
+			continue
+		}
+		for _, record := range records {
+			if record.Type == rs.Primary.Attributes["type"] {
+				return fmt.Errorf("Record set still exists")
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckDynRecordSetExists(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*dynClient)
+
+		records, err := client.GetRecordsByFQDN(rs.Primary.Attributes["zone"], rs.Primary.Attributes["fqdn"])
+		if err != nil {
+			return err
+		}
+		found := 0
+		for _, record := range records {
+			if record.Type == rs.Primary.Attributes["type"] {
+				found++
+			}
+		}
+		if found != count {
+			return fmt.Errorf("Expected %d records in the set, found %d", count, found)
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckDynRecordSetConfig_basic = `
+resource "dyn_record_set" "foobar" {
+  zone   = "%s"
+  name   = "record-set-test"
+  type   = "A"
+  values = ["192.168.0.10", "192.168.0.11", "192.168.0.12"]
+  ttl    = 3600
+}`
+
+const testAccCheckDynRecordSetConfig_updated = `
+resource "dyn_record_set" "foobar" {
+  zone   = "%s"
+  name   = "record-set-test"
+  type   = "A"
+  values = ["192.168.0.11", "192.168.0.13"]
+  ttl    = 300
+}`
+
+const testAccCheckDynRecordSetConfig_NS = `
+resource "dyn_record_set" "foobar" {
+  zone   = "%s"
+  name   = "delegated"
+  type   = "NS"
+  values = ["ns1.example.net", "ns2.example.net."]
+}`
+
+const testAccCheckDynRecordSetConfig_NSUpdated = `
+resource "dyn_record_set" "foobar" {
+  zone   = "%s"
+  name   = "delegated"
+  type   = "NS"
+  values = ["ns1.example.net", "ns3.example.net", "ns4.example.net"]
+}`
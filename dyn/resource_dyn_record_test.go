@@ -1,16 +1,317 @@
 package dyn
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
 	"os"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/nesv/go-dynect/dynect"
 )
 
+// rdataDyn stands in for Dyn's record API, keeping the RData each record is
+// created with and returning it when the record is read.
+type rdataDyn struct {
+	mu     sync.Mutex
+	rdata  map[string]json.RawMessage
+	nextID int
+}
+
+func (f *rdataDyn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// /REST/{type}Record/{zone}/{fqdn}[/{id}]
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/REST/"), "/")
+	if r.Method == "GET" && parts[0] == "AllRecord" {
+		fmt.Fprint(w, `{"status":"success","data":{}}`)
+		return
+	}
+	if len(parts) < 3 || !strings.HasSuffix(parts[0], "Record") {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"status":"failure","msgs":[{"INFO":"not found"}]}`)
+		return
+	}
+
+	var url string
+	switch r.Method {
+	case "POST":
+		var req struct {
+			RData json.RawMessage `json:"rdata"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.nextID++
+		parts = append(parts, fmt.Sprintf("%d", f.nextID))
+		url = strings.Join(parts, "/")
+		if f.rdata == nil {
+			f.rdata = make(map[string]json.RawMessage)
+		}
+		f.rdata[url] = req.RData
+	default:
+		url = strings.Join(parts, "/")
+	}
+	rdata, ok := f.rdata[url]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"status":"failure","msgs":[{"INFO":"not found"}]}`)
+		return
+	}
+	fmt.Fprintf(w, `{"status":"success","data":{"zone":%q,"fqdn":%q,"record_type":%q,"record_id":%s,"ttl":3600,"rdata":%s}}`,
+		parts[1], parts[2], strings.TrimSuffix(parts[0], "Record"), parts[3], rdata)
+}
+
+// rdataCase is a record value, the RData fields it should be sent to Dyn as
+// and the value it should be read back as.
+type rdataCase struct {
+	value string
+	sent  map[string]string
+	read  string
+}
+
+// testRecordRData creates a record of recordType for every case against
+// rdataDyn, checking what is sent and what is read back.
+func testRecordRData(t *testing.T, recordType string, cases []rdataCase) {
+	fake := &rdataDyn{}
+	client := dynect.NewConvenientClientWithHTTPClient("customer",
+		&http.Client{Transport: handlerTransport{fake}})
+	client.Logger = log.New(ioutil.Discard, "", 0)
+	client.Token = "token"
+
+	for _, tc := range cases {
+		record := &dynect.Record{
+			Zone:  "example.com",
+			Name:  "www",
+			Type:  recordType,
+			Value: tc.value,
+		}
+		if err := client.CreateRecord(record); err != nil {
+			t.Errorf("%s %q: err: %s", recordType, tc.value, err)
+			continue
+		}
+
+		var sent map[string]interface{}
+		url := fmt.Sprintf("%sRecord/example.com/www.example.com/%s", recordType, record.ID)
+		if err := json.Unmarshal(fake.rdata[url], &sent); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		for k, v := range tc.sent {
+			got, ok := sent[k]
+			if !ok {
+				got = ""
+			}
+			if fmt.Sprint(got) != v {
+				t.Errorf("%s %q: expected %s %q to be sent, got %q", recordType, tc.value, k, v, got)
+			}
+		}
+
+		if err := client.GetRecord(record); err != nil {
+			t.Errorf("%s %q: err: %s", recordType, tc.value, err)
+			continue
+		}
+		if record.Value != tc.read {
+			t.Errorf("%s %q: expected to read back %q, got %q", recordType, tc.value, tc.read, record.Value)
+		}
+	}
+}
+
+// suppressCase is a change to the value of a record, and whether the value
+// DiffSuppressFunc should hide it.
+type suppressCase struct {
+	old, new string
+	suppress bool
+}
+
+func testValueDiffSuppress(t *testing.T, recordType string, cases []suppressCase) {
+	res := resourceDynRecord()
+	suppress := res.Schema["value"].DiffSuppressFunc
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+			"zone":  "example.com",
+			"name":  "www",
+			"type":  recordType,
+			"value": tc.new,
+		})
+		if got := suppress("value", tc.old, tc.new, d); got != tc.suppress {
+			t.Errorf("%s %q -> %q: expected suppress %t, got %t", recordType, tc.old, tc.new, tc.suppress, got)
+		}
+	}
+}
+
+func TestDynRecord_rdataLOC(t *testing.T) {
+	// sizes and precisions default as in RFC 1876
+	testRecordRData(t, "LOC", []rdataCase{
+		{"51 30 12.748 N 0 7 39.611 W 0m",
+			map[string]string{"latitude": "51 30 12.748 N", "longitude": "0 7 39.611 W",
+				"altitude": "0", "size": "1", "horiz_pre": "10000", "vert_pre": "10"},
+			"51 30 12.748 N 0 7 39.611 W 0m 1m 10000m 10m"},
+		{"42 21 S 71 W -24m 30m 2 5m",
+			map[string]string{"latitude": "42 21 S", "longitude": "71 W",
+				"altitude": "-24", "size": "30", "horiz_pre": "2", "vert_pre": "5"},
+			"42 21 S 71 W -24m 30m 2m 5m"},
+	})
+}
+
+func TestResourceDynRecord_LOCDiffSuppress(t *testing.T) {
+	testValueDiffSuppress(t, "LOC", []suppressCase{
+		{"51 30 12.748 N 0 7 39.611 W 0m 1m 10000m 10m", "51 30 12.748 N 0 7 39.611 W 0m", true},
+		{"51 30 12.748 N 0 7 39.611 W 0m 1m 10000m 10m", "51 30 12.748 N 0 7 39.611 W 5m", false},
+	})
+}
+
+func TestDynRecord_rdataCAA(t *testing.T) {
+	// the value may be given with or without quotes
+	testRecordRData(t, "CAA", []rdataCase{
+		{`0 issue "letsencrypt.org"`,
+			map[string]string{"flags": "0", "tag": "issue", "value": "letsencrypt.org"},
+			`0 issue "letsencrypt.org"`},
+		{`0 iodef mailto:security@example.com`,
+			map[string]string{"flags": "0", "tag": "iodef", "value": "mailto:security@example.com"},
+			`0 iodef "mailto:security@example.com"`},
+	})
+}
+
+func TestResourceDynRecord_CAADiffSuppress(t *testing.T) {
+	testValueDiffSuppress(t, "CAA", []suppressCase{
+		{`0 issue "letsencrypt.org"`, `0 issue letsencrypt.org`, true},
+		{`0 issue "letsencrypt.org"`, `0 issue "letsencrypt.org"`, true},
+		{`0 issue "letsencrypt.org"`, `0 issue comodoca.com`, false},
+		{`0 issue "a \"b"`, `0 issue a "b`, true},
+	})
+}
+
+func TestDynRecord_rdataMX(t *testing.T) {
+	// a preference of 0 is sent, as for the null MX
+	testRecordRData(t, "MX", []rdataCase{
+		{"0 .", map[string]string{"preference": "0", "exchange": "."}, "0 ."},
+		{"10 mx.example.com.", map[string]string{"preference": "10", "exchange": "mx.example.com."}, "10 mx.example.com."},
+	})
+}
+
+func TestDynRecord_rdataTXT(t *testing.T) {
+	// values over 255 bytes are split into quoted segments
+	longTXT := strings.Repeat("a", 300)
+	testRecordRData(t, "TXT", []rdataCase{
+		{"v=spf1 -all", map[string]string{"txtdata": "v=spf1 -all"}, "v=spf1 -all"},
+		{longTXT, map[string]string{"txtdata": `"` + longTXT[:255] + `" "` + longTXT[255:] + `"`}, longTXT},
+	})
+}
+
+func TestResourceDynRecord_CNAMEDiffSuppress(t *testing.T) {
+	testValueDiffSuppress(t, "CNAME", []suppressCase{
+		{"www.example.com.", "www.example.com", true},
+		{"www.example.com.", "web.example.com", false},
+	})
+}
+
+func TestDynRecord_rdataIPSECKEY(t *testing.T) {
+	// each gateway type
+	testRecordRData(t, "IPSECKEY", []rdataCase{
+		{"10 0 2 . AQNRU3mG7TVTO2BkR47usntb102uFJtugbo6BSGvgqt4AQ==",
+			map[string]string{"gatetype": "0", "gateway": "", "public_key": "AQNRU3mG7TVTO2BkR47usntb102uFJtugbo6BSGvgqt4AQ=="},
+			"10 0 2 . AQNRU3mG7TVTO2BkR47usntb102uFJtugbo6BSGvgqt4AQ=="},
+		{"10 1 2 192.0.2.38 AQNRU3mG7TVTO2BkR47usntb102uFJtugbo6BSGvgqt4AQ==",
+			map[string]string{"gatetype": "1", "gateway": "192.0.2.38"},
+			"10 1 2 192.0.2.38 AQNRU3mG7TVTO2BkR47usntb102uFJtugbo6BSGvgqt4AQ=="},
+		{"10 2 2 2001:0DB8:0:8002::2000:1 AQNRU3mG7TVTO2BkR47usntb102uFJtugbo6BSGvgqt4AQ==",
+			map[string]string{"gatetype": "2", "gateway": "2001:0DB8:0:8002::2000:1"},
+			"10 2 2 2001:0DB8:0:8002::2000:1 AQNRU3mG7TVTO2BkR47usntb102uFJtugbo6BSGvgqt4AQ=="},
+		{"10 3 2 gateway.example.com AQNRU3mG7TVTO2BkR47u ntb102uFJtugbo6BSGvgqt4AQ==",
+			map[string]string{"gatetype": "3", "gateway": "gateway.example.com.",
+				"public_key": "AQNRU3mG7TVTO2BkR47untb102uFJtugbo6BSGvgqt4AQ=="},
+			"10 3 2 gateway.example.com. AQNRU3mG7TVTO2BkR47untb102uFJtugbo6BSGvgqt4AQ=="},
+	})
+}
+
+func TestResourceDynRecord_IPSECKEYDiffSuppress(t *testing.T) {
+	testValueDiffSuppress(t, "IPSECKEY", []suppressCase{
+		{"10 3 2 gateway.example.com. AQNRU3mG", "10 3 2 gateway.example.com AQNRU3mG", true},
+		{"10 3 2 gateway.example.com. AQNRU3mG", "10 3 2 gateway.example.net AQNRU3mG", false},
+	})
+}
+
+func TestResourceDynRecord_CERTDiffSuppress(t *testing.T) {
+	testValueDiffSuppress(t, "CERT", []suppressCase{
+		{"1 12345 8 MIIBIjAN", "PKIX 12345 8 MIIBIjAN", true},
+		{"1 12345 8 MIIBIjAN", "PKIX 12345 8 MIIB IjAN", true},
+		{"1 12345 8 MIIBIjAN", "1 12345 8 MIIBIjAM", false},
+	})
+}
+
+func TestResourceDynRecord_TLSADiffSuppress(t *testing.T) {
+	testValueDiffSuppress(t, "TLSA", []suppressCase{
+		{"3 1 1 0d6fce3a", "3 1 1 0d6f ce3a", true},
+		{"3 1 1 0d6fce3a", "3 1 1 0d6fce3a", true},
+		{"3 1 1 0d6fce3a", "3 1 2 0d6fce3a", false},
+	})
+}
+
+func TestDynRecord_rdataAAAA(t *testing.T) {
+	// addresses are sent and read in their canonical form
+	testRecordRData(t, "AAAA", []rdataCase{
+		{"2001:0DB8:0:0:0:0:0:1", map[string]string{"address": "2001:db8::1"}, "2001:db8::1"},
+		// an IPv4-mapped address is left as it is, not sent as IPv4
+		{"::ffff:192.0.2.1", map[string]string{"address": "::ffff:192.0.2.1"}, "::ffff:192.0.2.1"},
+	})
+}
+
+func TestResourceDynRecord_AAAADiffSuppress(t *testing.T) {
+	testValueDiffSuppress(t, "AAAA", []suppressCase{
+		{"2001:db8::1", "2001:0DB8:0:0:0:0:0:1", true},
+		{"2001:db8::1", "2001:db8::2", false},
+		{"::ffff:192.0.2.1", "::ffff:c000:201", true},
+		{"::ffff:192.0.2.1", "192.0.2.1", false},
+	})
+}
+
+func TestDynRecord_rdataNumbers(t *testing.T) {
+	// Dyn may return the numeric fields as JSON numbers or as strings
+	for _, data := range []string{
+		`{"flags":257,"format":1,"gatetype":3,"precendence":10,"tag":12345}`,
+		`{"flags":"257","format":"1","gatetype":"3","precendence":"10","tag":"12345"}`,
+	} {
+		var rdata dynect.DataBlock
+		if err := json.Unmarshal([]byte(data), &rdata); err != nil {
+			t.Fatalf("%s: err: %s", data, err)
+		}
+		got := fmt.Sprintf("%s %s %s %s %s", rdata.Flags, rdata.Format, rdata.GatewayType, rdata.Precendence, rdata.Tag)
+		if got != "257 1 3 10 12345" {
+			t.Errorf("%s: got %q", data, got)
+		}
+	}
+
+	// flags and tags are sent as numbers where they are numeric, and as
+	// strings where they are text, as for NAPTR and CAA
+	for _, tc := range []struct {
+		rdata dynect.DataBlock
+		sent  string
+	}{
+		{dynect.DataBlock{Flags: "257", Tag: "12345"}, `{"flags":257,"tag":12345}`},
+		{dynect.DataBlock{Flags: "U", Tag: "issue"}, `{"flags":"U","tag":"issue"}`},
+		{dynect.DataBlock{Flags: "0", Tag: "1e5x"}, `{"flags":0,"tag":"1e5x"}`},
+	} {
+		sent, err := json.Marshal(tc.rdata)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if string(sent) != tc.sent {
+			t.Errorf("expected %s to be sent, got %s", tc.sent, sent)
+		}
+	}
+}
+
 func TestAccDynRecord_Basic(t *testing.T) {
 	var record dynect.Record
 	zone := os.Getenv("DYN_ZONE")
@@ -37,6 +338,27 @@ func TestAccDynRecord_Basic(t *testing.T) {
 	})
 }
 
+func TestAccDynRecord_disappears(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_basic, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					testAccCheckDynRecordDisappears(&record),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func TestAccDynRecord_noTTL(t *testing.T) {
 	var record dynect.Record
 	zone := os.Getenv("DYN_ZONE")
@@ -62,6 +384,40 @@ func TestAccDynRecord_noTTL(t *testing.T) {
 	})
 }
 
+func TestAccDynRecord_zeroTTL(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_TTL, zone, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "ttl", "0"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_TTL, zone, 90),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "ttl", "90"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_TTL, zone, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "ttl", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccDynRecord_Updated(t *testing.T) {
 	var record dynect.Record
 	zone := os.Getenv("DYN_ZONE")
@@ -101,7 +457,710 @@ func TestAccDynRecord_Updated(t *testing.T) {
 	})
 }
 
-func TestAccDynRecord_Multiple(t *testing.T) {
+func TestAccDynRecord_renamed(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_basic, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar", "name", "terraform"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_renamed, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordGone(&record),
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar", "name", "terraform-renamed"),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar", "fqdn", "terraform-renamed."+zone),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar", "value", "192.168.0.10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_Multiple(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_multiple, zone, zone, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar1", &record),
+					testAccCheckDynRecordAttributes(&record),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar1", "name", "terraform1"),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar1", "zone", zone),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar1", "value", "192.168.0.10"),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar2", "name", "terraform2"),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar2", "zone", zone),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar2", "value", "192.168.1.10"),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar3", "name", "terraform3"),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar3", "zone", zone),
+					resource.TestCheckResourceAttr(
+						"dyn_record.foobar3", "value", "192.168.2.10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_roundRobin(t *testing.T) {
+	var first, second dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_roundRobin, zone, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar1", &first),
+					testAccCheckDynRecordExists("dyn_record.foobar2", &second),
+					resource.TestCheckResourceAttr("dyn_record.foobar1", "value", "192.168.0.10"),
+					resource.TestCheckResourceAttr("dyn_record.foobar2", "value", "192.168.0.11"),
+					func(s *terraform.State) error {
+						if first.ID == second.ID {
+							return fmt.Errorf("Both records share the ID %s", first.ID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_CNAME_trailingDot(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_CNAME_trailingDot, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "www"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "CNAME"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "ttl", "3600"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "something.terraform.io."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_CNAME_topLevelDomain(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_topLevelDomain, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "A"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "ttl", "90"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "127.0.0.1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_NS_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_NS_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "dev"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "NS"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "ttl", "3600"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "ns.terraform.io."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_MX_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_MX_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "mail-test"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "MX"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "ttl", "30"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "10 mx.terraform.io."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_SRV_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_SRV_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "_sip._udp"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "SRV"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "ttl", "3600"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "0 0 5060 sip.terraform.io."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_CAA_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_CAA_record, zone, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.issue", &record),
+					resource.TestCheckResourceAttr("dyn_record.issue", "type", "CAA"),
+					resource.TestCheckResourceAttr("dyn_record.issue", "value", "0 issue \"letsencrypt.org\""),
+					testAccCheckDynRecordExists("dyn_record.iodef", &record),
+					resource.TestCheckResourceAttr("dyn_record.iodef", "type", "CAA"),
+					resource.TestCheckResourceAttr("dyn_record.iodef", "value", "0 iodef \"mailto:security@terraform.io\""),
+				),
+			},
+			resource.TestStep{
+				// the same values without quotes, which Dyn reads back quoted
+				Config:   fmt.Sprintf(testAccCheckDynRecordConfig_CAA_unquoted, zone, zone),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_PTR_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_REVERSE_ZONE")
+	if zone == "" {
+		t.Skip("DYN_REVERSE_ZONE must be set to a reverse zone (e.g. 3.2.1.in-addr.arpa) to test PTR records")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_PTR_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "4"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "fqdn", "4."+zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "PTR"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "host.terraform.io."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_SSHFP_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_SSHFP_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "ssh-test"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "SSHFP"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "2 1 123456789abcdef67890123456789abcdef67890"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_TLSA_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_TLSA_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "_25._tcp.mail"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "TLSA"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "3 1 1 0D6FCE3A9BE8E6D8C8B1B5B0E4C3F9F2A1B6A0C7D4E8F1A2B3C4D5E6F7A8B9C0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_NAPTR_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_NAPTR_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "naptr-test"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "NAPTR"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_DNSKEY_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_DNSKEY_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "dnskey-test"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "DNSKEY"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "257 3 8 AwEAAagAIKlVZrpC6Ia7gEzahOR+9W29euxhJhVVLOyQbSEW0O8gcCjFFVQUTf6v58fLjwBd0YI0EzrAcQqBGCzh/RStIoO8g0NfnfL2MTJRkxoXbfDaUeVPQuYEhg37NZWAJQ9VnMVDxP/VHL496M/QZxkjf5/Efucp2gaDX6RS6CXpoY68LsvPVjR0ZSwzz1apAzvN9dlzEheX7ICJBBtuA6G3LQpzW5hOA2hzCTMjJPJ8LbqF6dsV6DoBQzgul0sGIcGOYl7OyQdXfZ57relSQageu+ipAdTTJ25AsRTAoub8ONGcLmqrAmRLKBP1dfwhYB4N7knNnulqQxA+Uk1ihz0="),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_DS_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_DS_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "child"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "DS"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "12345 8 2 49fd46e6c4b45c55d4ac69cbd3cd34ac1afe51de0e8a1e5d3c2a4d5b6e7f8091"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_LOC_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_LOC_record, zone, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.full", &record),
+					resource.TestCheckResourceAttr("dyn_record.full", "type", "LOC"),
+					resource.TestCheckResourceAttr("dyn_record.full", "value", "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000m 10m"),
+					testAccCheckDynRecordExists("dyn_record.minimal", &record),
+					resource.TestCheckResourceAttr("dyn_record.minimal", "type", "LOC"),
+					resource.TestCheckResourceAttr("dyn_record.minimal", "value", "52 22 N 4 53 E 2m 1m 10000m 10m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_CERT_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_CERT_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "cert-test"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "CERT"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "1 12345 8 MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAu1SU1LfVLPHCozMxH2Mo4lgOEePzNm0tRgeLezV6ffAt0gunVTLw7onLRnrq0/IzW7yWR7QkrmBL7jTKEn5u+qKhbwKfBstIs+bMY2Zkp18gnTxKLxoS2tFczGkPLPgizskuemMghRniWaoLcyehkd3qqGElvW/VDL5AaWTg0nLVkjRo9z+40RQzuVaE8AkAFmxZzow3x+VJYKdjykkJ0iT9wCS0DRTXu269V264Vf/3jvredZiKRkgwlL9xNAwxXFg0x/XFw005UWVRIkdgcKWTjpBP2dPwVZ4WWC+9aGVd+Gyn1o0CLelf4rEjGoXbAAEgAqeGUxrcIlbjXfbcmwIDAQAB"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_DHCID_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_DHCID_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "dhcid-test"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "DHCID"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "AAIBY2/AuCccgoJbsaxcQc9TUapptP69lOjxfNuVAA2kjEA="),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_AAAA_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_AAAA_record, zone, "2001:0DB8:0:0:0:0:0:1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "AAAA"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "2001:db8::1"),
+				),
+			},
+			// other spellings of the same address leave nothing to change
+			resource.TestStep{
+				Config:   fmt.Sprintf(testAccCheckDynRecordConfig_AAAA_record, zone, "2001:db8::1"),
+				PlanOnly: true,
+			},
+			resource.TestStep{
+				Config:   fmt.Sprintf(testAccCheckDynRecordConfig_AAAA_record, zone, "2001:db8:0::0:1"),
+				PlanOnly: true,
+			},
+			resource.TestStep{
+				Config:   fmt.Sprintf(testAccCheckDynRecordConfig_AAAA_record, zone, "2001:0db8:0000:0000:0000:0000:0000:0001"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_CSYNC_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_CSYNC_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "csync-test"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "CSYNC"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "66 3 A AAAA NS"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_IPSECKEY_ipv4Gateway(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_IPSECKEY_ipv4Gateway, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "ipseckey-ipv4"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "IPSECKEY"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "10 1 2 192.0.2.38 AQNRU3mG7TVTO2BkR47usntb102uFJtugbo6BSGvgqt4AQ=="),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_IPSECKEY_domainGateway(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_IPSECKEY_domainGateway, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "ipseckey-domain"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "IPSECKEY"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "10 3 2 gw.terraform.io. AQNRU3mG7TVTO2BkR47usntb102uFJtugbo6BSGvgqt4AQ=="),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_KEY_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_KEY_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "key-test"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "KEY"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "512 3 8 AwEAAcw5QLr0VmvcY1ZL0MEq4GXOfOuEZ/u/j8Fe1b5x0F5Ej6WsmfsCYLp2MJ2Nj2Hs1V1CpQ5Hsh2N9Kl5N+wuKDs="),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_KX_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_KX_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "kx-test"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "KX"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "10 kx.terraform.io."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_NSAP_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_NSAP_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "nsap-test"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "NSAP"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "0x47000580005a0000000001e133ffffff00016200"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_PX_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_PX_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "px-test"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "PX"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "10 terraform.io. px.terraform.io."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_RP_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_RP_record, zone, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.txt", &record),
+					resource.TestCheckResourceAttr("dyn_record.txt", "type", "RP"),
+					resource.TestCheckResourceAttr("dyn_record.txt", "value", "ops.terraform.io. contact.terraform.io."),
+					testAccCheckDynRecordExists("dyn_record.notxt", &record),
+					resource.TestCheckResourceAttr("dyn_record.notxt", "type", "RP"),
+					resource.TestCheckResourceAttr("dyn_record.notxt", "value", "ops.terraform.io. ."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_DNAME_record(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_DNAME_record, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "legacy"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "DNAME"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "terraform.io."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_DNAME_topLevelDomain(t *testing.T) {
 	var record dynect.Record
 	zone := os.Getenv("DYN_ZONE")
 
@@ -111,35 +1170,21 @@ func TestAccDynRecord_Multiple(t *testing.T) {
 		CheckDestroy: testAccCheckDynRecordDestroy,
 		Steps: []resource.TestStep{
 			resource.TestStep{
-				Config: fmt.Sprintf(testAccCheckDynRecordConfig_multiple, zone, zone, zone),
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_DNAME_topLevelDomain, zone),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckDynRecordExists("dyn_record.foobar1", &record),
-					testAccCheckDynRecordAttributes(&record),
-					resource.TestCheckResourceAttr(
-						"dyn_record.foobar1", "name", "terraform1"),
-					resource.TestCheckResourceAttr(
-						"dyn_record.foobar1", "zone", zone),
-					resource.TestCheckResourceAttr(
-						"dyn_record.foobar1", "value", "192.168.0.10"),
-					resource.TestCheckResourceAttr(
-						"dyn_record.foobar2", "name", "terraform2"),
-					resource.TestCheckResourceAttr(
-						"dyn_record.foobar2", "zone", zone),
-					resource.TestCheckResourceAttr(
-						"dyn_record.foobar2", "value", "192.168.1.10"),
-					resource.TestCheckResourceAttr(
-						"dyn_record.foobar3", "name", "terraform3"),
-					resource.TestCheckResourceAttr(
-						"dyn_record.foobar3", "zone", zone),
-					resource.TestCheckResourceAttr(
-						"dyn_record.foobar3", "value", "192.168.2.10"),
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "fqdn", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "DNAME"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "terraform.io."),
 				),
 			},
 		},
 	})
 }
 
-func TestAccDynRecord_CNAME_trailingDot(t *testing.T) {
+func TestAccDynRecord_CDS_delete(t *testing.T) {
 	var record dynect.Record
 	zone := os.Getenv("DYN_ZONE")
 
@@ -149,21 +1194,21 @@ func TestAccDynRecord_CNAME_trailingDot(t *testing.T) {
 		CheckDestroy: testAccCheckDynRecordDestroy,
 		Steps: []resource.TestStep{
 			resource.TestStep{
-				Config: fmt.Sprintf(testAccCheckDynRecordConfig_CNAME_trailingDot, zone),
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_CDS_delete, zone, zone),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckDynRecordExists("dyn_record.foobar", &record),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "www"),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "CNAME"),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "ttl", "3600"),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "something.terraform.io."),
+					testAccCheckDynRecordExists("dyn_record.cds", &record),
+					resource.TestCheckResourceAttr("dyn_record.cds", "type", "CDS"),
+					resource.TestCheckResourceAttr("dyn_record.cds", "value", "0 0 0 00"),
+					testAccCheckDynRecordExists("dyn_record.cdnskey", &record),
+					resource.TestCheckResourceAttr("dyn_record.cdnskey", "type", "CDNSKEY"),
+					resource.TestCheckResourceAttr("dyn_record.cdnskey", "value", "0 3 0 AA=="),
 				),
 			},
 		},
 	})
 }
 
-func TestAccDynRecord_CNAME_topLevelDomain(t *testing.T) {
+func TestAccDynRecord_TXT_longValue(t *testing.T) {
 	var record dynect.Record
 	zone := os.Getenv("DYN_ZONE")
 
@@ -173,21 +1218,20 @@ func TestAccDynRecord_CNAME_topLevelDomain(t *testing.T) {
 		CheckDestroy: testAccCheckDynRecordDestroy,
 		Steps: []resource.TestStep{
 			resource.TestStep{
-				Config: fmt.Sprintf(testAccCheckDynRecordConfig_topLevelDomain, zone),
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_TXT_longValue, zone),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckDynRecordExists("dyn_record.foobar", &record),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "name", zone),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "A"),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "ttl", "90"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "selector._domainkey"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "TXT"),
 					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "127.0.0.1"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA+GKEzJu+kt5p/STdIIP7pLKzbUgY6l5NlODnoogwA7nQEJeWxW4Q51tzzu8pTALEmR17sVzdoiae7smV95e6v3wo3IisXvc3BpOb/6hnZz8MlvnEzDNZtvAD7yJeK7+F7Zy4FKRlEbC6V/3qBIOY0Z7/686P3CAgOCESGogNEQ+xb8sbEhEmD7VKEnC+ddbfabuXkJmVKqcRZ0Sl91ImG+eGmczjxUiKoQ0svTk3gwqh0qDCZYDcHkBjkffkWYapmDeU3a4RPOPs11Y/3Q2ZQCyWrQGGwAPUhqtLtLaRC8I/cpDrSw42f1Ek5a9PnIXQkDIiIyjEyyM512kvf//WewIDAQAB"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccDynRecord_NS_record(t *testing.T) {
+func TestAccDynRecord_ALIAS_topLevelDomain(t *testing.T) {
 	var record dynect.Record
 	zone := os.Getenv("DYN_ZONE")
 
@@ -197,38 +1241,54 @@ func TestAccDynRecord_NS_record(t *testing.T) {
 		CheckDestroy: testAccCheckDynRecordDestroy,
 		Steps: []resource.TestStep{
 			resource.TestStep{
-				Config: fmt.Sprintf(testAccCheckDynRecordConfig_NS_record, zone),
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_ALIAS_topLevelDomain, zone),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckDynRecordExists("dyn_record.foobar", &record),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "dev"),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "NS"),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "ttl", "3600"),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "ns.terraform.io."),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "fqdn", zone),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "ALIAS"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "terraform.io."),
 				),
 			},
+			resource.TestStep{
+				// A refresh must read back the alias target, not an
+				// empty value or the address it resolves to.
+				Config:   fmt.Sprintf(testAccCheckDynRecordConfig_ALIAS_topLevelDomain, zone),
+				PlanOnly: true,
+			},
 		},
 	})
 }
 
-func TestAccDynRecord_MX_record(t *testing.T) {
+func TestAccDynRecord_SOA_record(t *testing.T) {
 	var record dynect.Record
-	zone := os.Getenv("DYN_ZONE")
+	zone := os.Getenv("DYN_NEW_ZONE")
+	if zone == "" {
+		t.Skip("DYN_NEW_ZONE must be set to the name of a zone which does not exist yet to test SOA records")
+	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckDynRecordDestroy,
+		CheckDestroy: testAccCheckDynZoneDestroy,
 		Steps: []resource.TestStep{
 			resource.TestStep{
-				Config: fmt.Sprintf(testAccCheckDynRecordConfig_MX_record, zone),
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_SOA_record, zone, 3600, 600),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckDynRecordExists("dyn_record.foobar", &record),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "mail-test"),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "MX"),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "ttl", "30"),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "zone", zone),
-					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "10 mx.terraform.io."),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "SOA"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "refresh", "3600"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "retry", "600"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "expire", "604800"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "minimum", "1800"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_SOA_record, zone, 7200, 900),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "refresh", "7200"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "retry", "900"),
 				),
 			},
 		},
@@ -236,7 +1296,7 @@ func TestAccDynRecord_MX_record(t *testing.T) {
 }
 
 func testAccCheckDynRecordDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*dynect.ConvenientClient)
+	client := testAccProvider.Meta().(*dynClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "dyn_record" {
@@ -252,7 +1312,7 @@ func testAccCheckDynRecordDestroy(s *terraform.State) error {
 
 		err := client.GetRecord(foundRecord)
 
-		if err != nil {
+		if err == nil {
 			return fmt.Errorf("Record still exists")
 		}
 	}
@@ -294,7 +1354,7 @@ func testAccCheckDynRecordExists(n string, record *dynect.Record) resource.TestC
 			return fmt.Errorf("No Record ID is set")
 		}
 
-		client := testAccProvider.Meta().(*dynect.ConvenientClient)
+		client := testAccProvider.Meta().(*dynClient)
 
 		foundRecord := &dynect.Record{
 			Zone: rs.Primary.Attributes["zone"],
@@ -319,6 +1379,36 @@ func testAccCheckDynRecordExists(n string, record *dynect.Record) resource.TestC
 	}
 }
 
+// testAccCheckDynRecordDisappears deletes record behind Terraform's back.
+func testAccCheckDynRecordDisappears(record *dynect.Record) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*dynClient)
+
+		if err := client.DeleteRecord(record); err != nil {
+			return err
+		}
+		return client.PublishZone(record.Zone)
+	}
+}
+
+// testAccCheckDynRecordGone checks that record, as last read, no longer
+// exists in Dyn, such as the old record of one which was renamed.
+func testAccCheckDynRecordGone(record *dynect.Record) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*dynClient)
+
+		old := *record
+		err := client.GetRecord(&old)
+		if err == nil {
+			return fmt.Errorf("Record %s still exists at %s", old.ID, old.FQDN)
+		}
+		if !errors.Is(err, dynect.ErrRecordNotFound) {
+			return err
+		}
+		return nil
+	}
+}
+
 const testAccCheckDynRecordConfig_basic = `
 resource "dyn_record" "foobar" {
 	zone = "%s"
@@ -337,6 +1427,15 @@ resource "dyn_record" "foobar" {
 	ttl = 3600
 }`
 
+const testAccCheckDynRecordConfig_renamed = `
+resource "dyn_record" "foobar" {
+	zone = "%s"
+	name = "terraform-renamed"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 3600
+}`
+
 const testAccCheckDynRecordConfig_multiple = `
 resource "dyn_record" "foobar1" {
 	zone = "%s"
@@ -360,6 +1459,22 @@ resource "dyn_record" "foobar3" {
 	ttl = 3600
 }`
 
+const testAccCheckDynRecordConfig_roundRobin = `
+resource "dyn_record" "foobar1" {
+	zone = "%s"
+	name = "terraform-rr"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 3600
+}
+resource "dyn_record" "foobar2" {
+	zone = "%s"
+	name = "terraform-rr"
+	value = "192.168.0.11"
+	type = "A"
+	ttl = 3600
+}`
+
 const testAccCheckDynRecordConfig_noTTL = `
 resource "dyn_record" "foobar" {
 	zone = "%s"
@@ -368,6 +1483,15 @@ resource "dyn_record" "foobar" {
 	type = "A"
 }`
 
+const testAccCheckDynRecordConfig_TTL = `
+resource "dyn_record" "foobar" {
+	zone = "%s"
+	name = "terraform"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = %d
+}`
+
 const testAccCheckDynRecordConfig_CNAME_trailingDot = `
 resource "dyn_record" "foobar" {
   zone  = "%s"
@@ -402,3 +1526,284 @@ resource "dyn_record" "foobar" {
   type  = "MX"
   ttl   = 30
 }`
+
+const testAccCheckDynRecordConfig_SRV_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "_sip._udp"
+  value = "0 0 5060 sip.terraform.io"
+  type  = "SRV"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_CAA_record = `
+resource "dyn_record" "issue" {
+  zone  = "%s"
+  name  = "caa-issue"
+  value = "0 issue \"letsencrypt.org\""
+  type  = "CAA"
+  ttl   = 3600
+}
+resource "dyn_record" "iodef" {
+  zone  = "%s"
+  name  = "caa-iodef"
+  value = "0 iodef \"mailto:security@terraform.io\""
+  type  = "CAA"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_CAA_unquoted = `
+resource "dyn_record" "issue" {
+  zone  = "%s"
+  name  = "caa-issue"
+  value = "0 issue letsencrypt.org"
+  type  = "CAA"
+  ttl   = 3600
+}
+resource "dyn_record" "iodef" {
+  zone  = "%s"
+  name  = "caa-iodef"
+  value = "0 iodef mailto:security@terraform.io"
+  type  = "CAA"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_PTR_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "4"
+  value = "host.terraform.io"
+  type  = "PTR"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_SSHFP_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "ssh-test"
+  value = "2 1 123456789ABCDEF67890123456789ABCDEF67890"
+  type  = "SSHFP"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_TLSA_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "_25._tcp.mail"
+  value = "3 1 1 0D6FCE3A9BE8E6D8C8B1B5B0E4C3F9F2A1B6A0C7D4E8F1A2B3C4D5E6F7A8B9C0"
+  type  = "TLSA"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_NAPTR_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "naptr-test"
+  value = "100 10 \"U\" \"E2U+sip\" \"!^.*$!sip:info@example.com!\" ."
+  type  = "NAPTR"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_DNSKEY_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "dnskey-test"
+  value = "257 3 8 AwEAAagAIKlVZrpC6Ia7gEzahOR+9W29euxhJhVVLOyQbSEW0O8gcCjFFVQUTf6v58fLjwBd0YI0EzrAcQqBGCzh/RStIoO8g0NfnfL2MTJRkxoXbfDaUeVPQuYEhg37NZWAJQ9VnMVDxP/VHL496M/QZxkjf5/Efucp2gaDX6RS6CXpoY68LsvPVjR0ZSwzz1apAzvN9dlzEheX7ICJBBtuA6G3LQpzW5hOA2hzCTMjJPJ8LbqF6dsV6DoBQzgul0sGIcGOYl7OyQdXfZ57relSQageu+ipAdTTJ25AsRTAoub8ONGcLmqrAmRLKBP1dfwhYB4N7knNnulqQxA+Uk1ihz0="
+  type  = "DNSKEY"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_DS_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "child"
+  value = "12345 8 2 49FD46E6C4B45C55D4AC69CBD3CD34AC1AFE51DE0E8A1E5D3C2A4D5B6E7F8091"
+  type  = "DS"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_LOC_record = `
+resource "dyn_record" "full" {
+  zone  = "%s"
+  name  = "loc-full"
+  value = "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000m 10m"
+  type  = "LOC"
+  ttl   = 3600
+}
+resource "dyn_record" "minimal" {
+  zone  = "%s"
+  name  = "loc-minimal"
+  value = "52 22 N 4 53 E 2m"
+  type  = "LOC"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_CERT_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "cert-test"
+  value = "PKIX 12345 8 MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAu1SU1LfVLPHCozMxH2Mo4lgOEePzNm0tRgeLezV6ffAt0gunVTLw7onLRnrq0/IzW7yWR7QkrmBL7jTKEn5u+qKhbwKfBstIs+bMY2Zkp18gnTxKLxoS2tFczGkPLPgizskuemMghRniWaoLcyehkd3qqGElvW/VDL5AaWTg0nLVkjRo9z+40RQzuVaE8AkAFmxZzow3x+VJYKdjykkJ0iT9wCS0DRTXu269V264Vf/3jvredZiKRkgwlL9xNAwxXFg0x/XFw005UWVRIkdgcKWTjpBP2dPwVZ4WWC+9aGVd+Gyn1o0CLelf4rEjGoXbAAEgAqeGUxrcIlbjXfbcmwIDAQAB"
+  type  = "CERT"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_DHCID_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "dhcid-test"
+  value = "AAIBY2/AuCccgoJbsaxcQc9TUapptP69lOjxfNuVAA2kjEA="
+  type  = "DHCID"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_AAAA_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "aaaa-test"
+  value = "%s"
+  type  = "AAAA"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_CSYNC_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "csync-test"
+  value = "66 3 NS a aaaa"
+  type  = "CSYNC"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_IPSECKEY_ipv4Gateway = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "ipseckey-ipv4"
+  value = "10 1 2 192.0.2.38 AQNRU3mG7TVTO2BkR47usntb102uFJtugbo6BSGvgqt4AQ=="
+  type  = "IPSECKEY"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_IPSECKEY_domainGateway = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "ipseckey-domain"
+  value = "10 3 2 gw.terraform.io AQNRU3mG7TVTO2BkR47usntb102uFJtugbo6BSGvgqt4AQ=="
+  type  = "IPSECKEY"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_KEY_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "key-test"
+  value = "512 3 8 AwEAAcw5QLr0VmvcY1ZL0MEq4GXOfOuEZ/u/j8Fe1b5x0F5Ej6WsmfsCYLp2MJ2Nj2Hs1V1CpQ5Hsh2N9Kl5N+wuKDs="
+  type  = "KEY"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_KX_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "kx-test"
+  value = "10 kx.terraform.io"
+  type  = "KX"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_NSAP_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "nsap-test"
+  value = "47.0005.80.005A00.0000.0001.E133.FFFFFF000162.00"
+  type  = "NSAP"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_PX_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "px-test"
+  value = "10 terraform.io px.terraform.io"
+  type  = "PX"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_RP_record = `
+resource "dyn_record" "txt" {
+  zone  = "%s"
+  name  = "rp-txt"
+  value = "ops.terraform.io contact.terraform.io"
+  type  = "RP"
+  ttl   = 3600
+}
+resource "dyn_record" "notxt" {
+  zone  = "%s"
+  name  = "rp-notxt"
+  value = "ops.terraform.io ."
+  type  = "RP"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_DNAME_record = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "legacy"
+  value = "terraform.io"
+  type  = "DNAME"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_DNAME_topLevelDomain = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  value = "terraform.io"
+  type  = "DNAME"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_CDS_delete = `
+resource "dyn_record" "cds" {
+  zone  = "%s"
+  value = "0 0 0 00"
+  type  = "CDS"
+  ttl   = 3600
+}
+resource "dyn_record" "cdnskey" {
+  zone  = "%s"
+  value = "0 3 0 AA=="
+  type  = "CDNSKEY"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_TXT_longValue = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "selector._domainkey"
+  value = "v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA+GKEzJu+kt5p/STdIIP7pLKzbUgY6l5NlODnoogwA7nQEJeWxW4Q51tzzu8pTALEmR17sVzdoiae7smV95e6v3wo3IisXvc3BpOb/6hnZz8MlvnEzDNZtvAD7yJeK7+F7Zy4FKRlEbC6V/3qBIOY0Z7/686P3CAgOCESGogNEQ+xb8sbEhEmD7VKEnC+ddbfabuXkJmVKqcRZ0Sl91ImG+eGmczjxUiKoQ0svTk3gwqh0qDCZYDcHkBjkffkWYapmDeU3a4RPOPs11Y/3Q2ZQCyWrQGGwAPUhqtLtLaRC8I/cpDrSw42f1Ek5a9PnIXQkDIiIyjEyyM512kvf//WewIDAQAB"
+  type  = "TXT"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_ALIAS_topLevelDomain = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  value = "terraform.io"
+  type  = "ALIAS"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_SOA_record = `
+resource "dyn_zone" "foobar" {
+  zone  = "%s"
+  rname = "hostmaster@terraform.io"
+}
+
+resource "dyn_record" "foobar" {
+  zone    = "${dyn_zone.foobar.zone}"
+  value   = "hostmaster.terraform.io."
+  type    = "SOA"
+  refresh = %d
+  retry   = %d
+  expire  = 604800
+  minimum = 1800
+}`
@@ -0,0 +1,212 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func resourceDynZone() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynZoneCreate,
+		Read:   resourceDynZoneRead,
+		Update: resourceDynZoneUpdate,
+		Delete: resourceDynZoneDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"rname": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				DiffSuppressFunc: func(k, oldV, newV string, d *schema.ResourceData) bool {
+					return normalizeRName(oldV) == normalizeRName(newV)
+				},
+			},
+
+			"ttl": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  3600,
+			},
+
+			"serial_style": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "increment",
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					switch v.(string) {
+					case "increment", "epoch", "day", "minute":
+					default:
+						es = append(es, fmt.Errorf("%q must be one of increment, epoch, day or minute, got %q", k, v))
+					}
+					return
+				},
+			},
+
+			"serial": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"zone_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDynZoneCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	rname := d.Get("rname").(string)
+	ttl := d.Get("ttl").(int)
+	serialStyle := d.Get("serial_style").(string)
+	log.Printf("[DEBUG] Dyn zone create configuration: %s, %s, %d, %s", zone, rname, ttl, serialStyle)
+
+	// create the zone
+	err := client.CreateZoneContext(ctx, zone, rname, serialStyle, ttl)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to create Dyn zone: %s", err)
+	}
+	d.SetId(zone)
+
+	// publish the zone
+	err = publishZoneContext(ctx, client, zone)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
+	}
+
+	mutex.Unlock()
+	return resourceDynZoneRead(d, meta)
+}
+
+func resourceDynZoneRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+
+	zone, err := client.GetZone(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Dyn zone %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Couldn't find Dyn zone: %s", err)
+	}
+
+	// The rname and TTL are those of the zone's SOA record
+	soa, err := client.GetZoneSOA(d.Id())
+	if err != nil {
+		return fmt.Errorf("Couldn't read Dyn zone SOA record: %s", err)
+	}
+
+	d.Set("zone", zone.Data.Zone)
+	d.Set("serial", zone.Data.Serial)
+	d.Set("serial_style", zone.Data.SerialStyle)
+	d.Set("zone_type", zone.Data.ZoneType)
+	d.Set("rname", soa.RName)
+	d.Set("ttl", soa.TTL)
+
+	return nil
+}
+
+func resourceDynZoneUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	client := meta.(*dynClient)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	zone := d.Id()
+
+	if d.HasChange("serial_style") {
+		err := client.SetZoneSerialStyleContext(ctx, zone, d.Get("serial_style").(string))
+		if err != nil {
+			mutex.Unlock()
+			return fmt.Errorf("Failed to update Dyn zone serial style: %s", err)
+		}
+	}
+
+	if d.HasChange("rname") || d.HasChange("ttl") {
+		// update the SOA record
+		err := client.UpdateZoneSOAContext(ctx, zone, dynect.SOAData{
+			RName:       d.Get("rname").(string),
+			TTL:         d.Get("ttl").(int),
+			SerialStyle: d.Get("serial_style").(string),
+		})
+		forgetZoneDefaultTTL(client, zone)
+		if err != nil {
+			mutex.Unlock()
+			return fmt.Errorf("Failed to update Dyn zone SOA record: %s", err)
+		}
+
+		// publish the zone
+		err = publishZoneContext(ctx, client, zone)
+		if err != nil {
+			mutex.Unlock()
+			return fmt.Errorf("Failed to publish Dyn zone: %s", err)
+		}
+	}
+
+	mutex.Unlock()
+	return resourceDynZoneRead(d, meta)
+}
+
+func resourceDynZoneDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*dynClient)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	log.Printf("[INFO] Deleting Dyn zone: %s", d.Id())
+
+	// delete the zone
+	err := client.DeleteZoneContext(ctx, d.Id())
+	if err != nil {
+		return fmt.Errorf("Failed to delete Dyn zone: %s", err)
+	}
+
+	return nil
+}
+
+// normalizeRName rewrites an SOA rname given as an email address, such as
+// admin@example.com, to the domain name form admin.example.com. that it is
+// stored in.
+func normalizeRName(v string) string {
+	v = strings.Replace(v, "@", ".", 1)
+	if !strings.HasSuffix(v, ".") {
+		v += "."
+	}
+	return strings.ToLower(v)
+}
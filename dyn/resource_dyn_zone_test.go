@@ -0,0 +1,101 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynZone_Basic(t *testing.T) {
+	zone := os.Getenv("DYN_NEW_ZONE")
+	if zone == "" {
+		t.Skip("DYN_NEW_ZONE must be set to the name of a zone which does not exist yet to test zones")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynZoneDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynZoneConfig_basic, zone, 3600, "increment"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynZoneExists("dyn_zone.foobar"),
+					resource.TestCheckResourceAttr("dyn_zone.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_zone.foobar", "ttl", "3600"),
+					resource.TestCheckResourceAttr("dyn_zone.foobar", "serial_style", "increment"),
+					resource.TestCheckResourceAttr("dyn_zone.foobar", "zone_type", "Primary"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckDynZoneConfig_basic, zone, 1800, "epoch"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynZoneExists("dyn_zone.foobar"),
+					resource.TestCheckResourceAttr("dyn_zone.foobar", "ttl", "1800"),
+					resource.TestCheckResourceAttr("dyn_zone.foobar", "serial_style", "epoch"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "dyn_zone.foobar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDynZoneDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*dynClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_zone" {
+			continue
+		}
+
+		_, err := client.GetZone(rs.Primary.ID)
+
+		if err == nil {
+			return fmt.Errorf("Zone still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckDynZoneExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Zone ID is set")
+		}
+
+		client := testAccProvider.Meta().(*dynClient)
+
+		zone, err := client.GetZone(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if zone.Data.Zone != rs.Primary.ID {
+			return fmt.Errorf("Zone not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckDynZoneConfig_basic = `
+resource "dyn_zone" "foobar" {
+  zone         = "%s"
+  rname        = "hostmaster@terraform.io"
+  ttl          = %d
+  serial_style = "%s"
+}`